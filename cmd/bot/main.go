@@ -1,8 +1,13 @@
 package main
 
 import (
+	"context"
+	"flag"
 	"fmt"
 	"log"
+	"os"
+	"os/signal"
+	"syscall"
 
 	"github.com/polyk005/tg_bot/internal/app"
 	"github.com/polyk005/tg_bot/internal/config"
@@ -10,9 +15,12 @@ import (
 )
 
 func main() {
+	configPath := flag.String("config", config.DefaultPath(), "path to config file, empty to use only environment variables")
+	flag.Parse()
+
 	fmt.Println("Starting Telegram Bot...")
 
-	cfg, err := config.Load("config.yaml")
+	cfg, err := config.Load(*configPath)
 	if err != nil {
 		log.Fatalf("Failed to load config: %v", err)
 	}
@@ -20,12 +28,23 @@ func main() {
 	log := logger.New(cfg.LogLevel)
 	defer log.Sync()
 
+	// Отменяется по SIGINT/SIGTERM, после чего бот завершает начатые обработчики
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	bot, err := app.NewBot(cfg, log)
 	if err != nil {
 		log.Fatal("Failed to create bot", "error", err)
 	}
 
-	if err := bot.Run(); err != nil {
-		log.Fatal("Bot stopped with error", "error", err)
+	// Настройки, которые можно менять на лету, перечитываются по SIGHUP и при изменении файла
+	watcher := config.NewWatcher(*configPath, cfg, log)
+	watcher.Subscribe(bot.ApplyConfig)
+	go watcher.Run(ctx, cfg.ReloadInterval)
+
+	if err := bot.Run(ctx); err != nil {
+		log.Errorw("Bot stopped with error", "error", err)
+		log.Sync()
+		os.Exit(1)
 	}
 }
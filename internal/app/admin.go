@@ -0,0 +1,133 @@
+package app
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// readinessTimeout ограничивает время одной проверки готовности
+const readinessTimeout = 5 * time.Second
+
+// pinger реализуют репозитории, которые умеют проверять соединение с хранилищем
+type pinger interface {
+	Ping(ctx context.Context) error
+}
+
+// startAdmin запускает служебный HTTP-сервер с /healthz, /readyz, /metrics и /loglevel.
+// Чтение не требует авторизации, поэтому сервер должен быть доступен только изнутри;
+// изменение уровня логирования проверяется в authorized.
+// Возвращает функцию остановки сервера.
+func (b *Bot) startAdmin() func() {
+	server := &http.Server{
+		Addr:              b.adminListen,
+		Handler:           b.adminHandler(),
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	go func() {
+		if err := server.ListenAndServe(); !errors.Is(err, http.ErrServerClosed) {
+			b.logger.Errorw("Admin server failed", "error", err, "listen", b.adminListen)
+		}
+	}()
+	b.logger.Infow("Admin server started", "listen", b.adminListen)
+
+	return func() {
+		ctx, cancel := context.WithTimeout(context.Background(), b.shutdownTimeout)
+		defer cancel()
+		if err := server.Shutdown(ctx); err != nil {
+			b.logger.Errorw("Failed to stop admin server", "error", err)
+		}
+	}
+}
+
+// adminHandler собирает маршруты служебного сервера
+func (b *Bot) adminHandler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/healthz", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ok\n"))
+	})
+	mux.HandleFunc("/readyz", b.readyHandler)
+	mux.Handle("/metrics", b.metrics.Handler())
+	mux.HandleFunc("/loglevel", b.logLevelHandler)
+	return mux
+}
+
+// readyHandler проверяет хранилище и доступность Telegram Bot API.
+// Отвечает 503, если хотя бы одна проверка не прошла.
+func (b *Bot) readyHandler(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithTimeout(r.Context(), readinessTimeout)
+	defer cancel()
+
+	checks := map[string]string{
+		"repository": "ok",
+		"telegram":   "ok",
+	}
+	status := http.StatusOK
+
+	if p, ok := b.repo.(pinger); ok {
+		if err := p.Ping(ctx); err != nil {
+			checks["repository"] = err.Error()
+			status = http.StatusServiceUnavailable
+		}
+	}
+
+	if _, err := b.tgBot.GetMe(ctx); err != nil {
+		checks["telegram"] = err.Error()
+		status = http.StatusServiceUnavailable
+	}
+
+	if status != http.StatusOK {
+		b.logger.Warnw("Readiness check failed", "checks", checks)
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(checks)
+}
+
+// logLevelHandler показывает (GET) или меняет (PUT/POST, level=debug|info|warn|error) уровень логирования
+func (b *Bot) logLevelHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case http.MethodGet:
+	case http.MethodPut, http.MethodPost:
+		if !b.authorized(r) {
+			b.logger.Warnw("Unauthorized log level change", "remote", r.RemoteAddr)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+		level := strings.ToLower(strings.TrimSpace(r.FormValue("level")))
+		if err := b.logger.SetLevel(level); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		b.logger.Infow("Log level changed", "level", level, "remote", r.RemoteAddr)
+	default:
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	_, _ = w.Write([]byte(b.logger.Level() + "\n"))
+}
+
+// authorized проверяет право менять настройки: заголовок Authorization: Bearer
+// с admin.token, а если токен не задан - запрос с loopback-адреса
+func (b *Bot) authorized(r *http.Request) bool {
+	if b.adminToken != "" {
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		return ok && subtle.ConstantTimeCompare([]byte(token), []byte(b.adminToken)) == 1
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && ip.IsLoopback()
+}
@@ -0,0 +1,234 @@
+package app
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/config"
+	"github.com/polyk005/tg_bot/internal/metrics"
+	"github.com/polyk005/tg_bot/internal/repository/inmemory"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// brokenRepo - репозиторий, хранилище которого недоступно
+type brokenRepo struct {
+	*inmemory.InMemoryRepository
+}
+
+func (brokenRepo) Ping(ctx context.Context) error {
+	return errors.New("connection refused")
+}
+
+func newAdminTestBot(t *testing.T, api *fakeBotAPI) *Bot {
+	t.Helper()
+	return &Bot{
+		tgBot:           api.bot(t),
+		repo:            inmemory.New(),
+		logger:          logger.New("error"),
+		metrics:         metrics.New(),
+		shutdownTimeout: time.Second,
+	}
+}
+
+func get(t *testing.T, h http.Handler, path string) (int, string) {
+	t.Helper()
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	body, _ := io.ReadAll(rec.Body)
+	return rec.Code, string(body)
+}
+
+func TestHealthz(t *testing.T) {
+	b := newAdminTestBot(t, newFakeBotAPI(t))
+
+	code, body := get(t, b.adminHandler(), "/healthz")
+	if code != http.StatusOK || body != "ok\n" {
+		t.Fatalf("GET /healthz = %d %q", code, body)
+	}
+}
+
+func TestReadyz(t *testing.T) {
+	tests := []struct {
+		name       string
+		brokenRepo bool
+		telegram   int // статус ответа getMe, 0 - успех
+		wantCode   int
+		wantChecks map[string]string
+	}{
+		{
+			name:       "ready",
+			wantCode:   http.StatusOK,
+			wantChecks: map[string]string{"repository": "ok", "telegram": "ok"},
+		},
+		{
+			name:       "repository down",
+			brokenRepo: true,
+			wantCode:   http.StatusServiceUnavailable,
+			wantChecks: map[string]string{"repository": "connection refused", "telegram": "ok"},
+		},
+		{
+			name:     "telegram down",
+			telegram: http.StatusUnauthorized,
+			wantCode: http.StatusServiceUnavailable,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeBotAPI(t)
+			if tt.telegram != 0 {
+				api.respond("getMe", tt.telegram, `{"ok":false,"error_code":401,"description":"Unauthorized"}`)
+			}
+			b := newAdminTestBot(t, api)
+			if tt.brokenRepo {
+				b.repo = brokenRepo{inmemory.New()}
+			}
+
+			code, body := get(t, b.adminHandler(), "/readyz")
+			if code != tt.wantCode {
+				t.Fatalf("GET /readyz = %d, want %d (%s)", code, tt.wantCode, body)
+			}
+
+			var checks map[string]string
+			if err := json.Unmarshal([]byte(body), &checks); err != nil {
+				t.Fatalf("decode body %q: %v", body, err)
+			}
+			if tt.telegram != 0 && checks["telegram"] == "ok" {
+				t.Errorf("telegram check = ok, want an error")
+			}
+			for k, v := range tt.wantChecks {
+				if checks[k] != v {
+					t.Errorf("checks[%s] = %q, want %q", k, checks[k], v)
+				}
+			}
+			if !api.called("getMe") {
+				t.Error("readiness did not call getMe")
+			}
+		})
+	}
+}
+
+func TestMetricsEndpoint(t *testing.T) {
+	b := newAdminTestBot(t, newFakeBotAPI(t))
+	b.metrics.ObserveUpdate("/today", 150*time.Millisecond)
+	b.metrics.ObserveAICall("question", nil, 12, 30)
+	b.metrics.ObserveAICall("question", errors.New("timeout"), 0, 0)
+	b.metrics.RegisterSessions("edit", func() int { return 3 })
+
+	code, body := get(t, b.adminHandler(), "/metrics")
+	if code != http.StatusOK {
+		t.Fatalf("GET /metrics = %d", code)
+	}
+	for _, want := range []string{
+		`tg_bot_updates_total{command="/today"} 1`,
+		`tg_bot_handler_duration_seconds_count{command="/today"} 1`,
+		`tg_bot_ai_calls_total{operation="question",result="ok"} 1`,
+		`tg_bot_ai_calls_total{operation="question",result="error"} 1`,
+		`tg_bot_ai_tokens_total{kind="prompt",operation="question"} 12`,
+		`tg_bot_ai_tokens_total{kind="completion",operation="question"} 30`,
+		`tg_bot_active_sessions{kind="edit"} 3`,
+		`go_goroutines`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("/metrics has no %q", want)
+		}
+	}
+}
+
+func TestLogLevelEndpoint(t *testing.T) {
+	b := newAdminTestBot(t, newFakeBotAPI(t))
+	h := b.adminHandler()
+
+	rec := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPut, "/loglevel", strings.NewReader(url.Values{"level": {"debug"}}.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.RemoteAddr = "127.0.0.1:40000"
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK || b.logger.Level() != "debug" {
+		t.Fatalf("PUT /loglevel = %d, level %s", rec.Code, b.logger.Level())
+	}
+
+	rec = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodPost, "/loglevel?level=verbose", nil)
+	req.RemoteAddr = "127.0.0.1:40000"
+	h.ServeHTTP(rec, req)
+	if rec.Code != http.StatusBadRequest || b.logger.Level() != "debug" {
+		t.Fatalf("POST /loglevel?level=verbose = %d, level %s", rec.Code, b.logger.Level())
+	}
+
+	if code, body := get(t, h, "/loglevel"); code != http.StatusOK || body != "debug\n" {
+		t.Fatalf("GET /loglevel = %d %q", code, body)
+	}
+}
+
+func TestLogLevelEndpointAuthorization(t *testing.T) {
+	tests := []struct {
+		name     string
+		token    string // admin.token
+		remote   string
+		header   string // Authorization
+		wantCode int
+	}{
+		{name: "no token, localhost", remote: "127.0.0.1:40000", wantCode: http.StatusOK},
+		{name: "no token, ipv6 localhost", remote: "[::1]:40000", wantCode: http.StatusOK},
+		{name: "no token, remote", remote: "203.0.113.7:40000", wantCode: http.StatusForbidden},
+		{name: "token", token: "s3cret", remote: "203.0.113.7:40000", header: "Bearer s3cret", wantCode: http.StatusOK},
+		{name: "wrong token", token: "s3cret", remote: "203.0.113.7:40000", header: "Bearer guess", wantCode: http.StatusForbidden},
+		{name: "token required on localhost", token: "s3cret", remote: "127.0.0.1:40000", wantCode: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			b := newAdminTestBot(t, newFakeBotAPI(t))
+			b.adminToken = tt.token
+
+			rec := httptest.NewRecorder()
+			req := httptest.NewRequest(http.MethodPut, "/loglevel?level=debug", nil)
+			req.RemoteAddr = tt.remote
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+			b.adminHandler().ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Fatalf("PUT /loglevel = %d, want %d", rec.Code, tt.wantCode)
+			}
+			wantLevel := "error"
+			if tt.wantCode == http.StatusOK {
+				wantLevel = "debug"
+			}
+			if b.logger.Level() != wantLevel {
+				t.Errorf("level = %s, want %s", b.logger.Level(), wantLevel)
+			}
+		})
+	}
+}
+
+func TestApplyConfigKeepsRuntimeLogLevel(t *testing.T) {
+	b := newAdminTestBot(t, newFakeBotAPI(t))
+	b.service = service.New(inmemory.New(), service.NewAIService("", nil), b.logger, service.Options{})
+	b.configLogLevel = "error"
+
+	cfg := &config.Config{LogLevel: "error"}
+	_ = b.logger.SetLevel("debug") // /loglevel во время работы
+
+	b.ApplyConfig(cfg)
+	if b.logger.Level() != "debug" {
+		t.Fatalf("reload without log_level change reset the level to %s", b.logger.Level())
+	}
+
+	cfg.LogLevel = "warn"
+	b.ApplyConfig(cfg)
+	if b.logger.Level() != "warn" {
+		t.Errorf("level = %s after log_level changed to warn", b.logger.Level())
+	}
+}
@@ -2,45 +2,256 @@ package app
 
 import (
 	"context"
+	"fmt"
+	"io"
+	"time"
 
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 	"github.com/polyk005/tg_bot/internal/config"
 	"github.com/polyk005/tg_bot/internal/delivery/telegram"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/metrics"
 	"github.com/polyk005/tg_bot/internal/repository/inmemory"
 	"github.com/polyk005/tg_bot/internal/service"
 	"github.com/polyk005/tg_bot/pkg/logger"
 )
 
+// pollTimeout - таймаут long polling и запросов к Bot API (как в go-telegram/bot по умолчанию)
+const pollTimeout = time.Minute
+
 type Bot struct {
-	tgBot   *bot.Bot
-	service *service.Service
-	logger  logger.Logger
+	tgBot    *bot.Bot
+	service  *service.Service
+	repo     domain.Repository
+	logger   logger.Logger
+	inflight *inflight
+
+	shutdownTimeout time.Duration
+	// webhook используется, если webhookPath не пустой
+	webhook     config.WebhookConfig
+	webhookPath string
+
+	metrics     *metrics.Metrics
+	adminListen string
+	adminToken  string
+	// configLogLevel - log_level из последнего примененного конфига. Уровень,
+	// измененный во время работы, сохраняется, пока log_level в конфиге не изменится
+	configLogLevel string
+	// hasOpenAIKey нужен при перезагрузке конфига: без ключа разбор через AI не включается
+	hasOpenAIKey bool
 }
 
 func NewBot(cfg *config.Config, log logger.Logger) (*Bot, error) {
 	repo := inmemory.New()
-	aiService := service.NewAIService(cfg.OpenAIKey)
-	svc := service.New(repo, aiService, log)
+	m := metrics.New()
+	aiService := service.NewAIService(cfg.OpenAIKey, m)
+	aiService.SetParams(aiParams(cfg))
+
+	bells, err := bellSchedules(cfg)
+	if err != nil {
+		log.Error("Invalid bell schedules", "error", err)
+		return nil, err
+	}
+
+	location := time.Local
+	if cfg.TimeZone != "" {
+		location, err = time.LoadLocation(cfg.TimeZone)
+		if err != nil {
+			log.Error("Invalid time zone", "error", err)
+			return nil, err
+		}
+	}
+
+	holidays, err := holidayDates(cfg)
+	if err != nil {
+		log.Error("Invalid holidays", "error", err)
+		return nil, err
+	}
+
+	campus, err := campusDirectory(cfg)
+	if err != nil {
+		log.Error("Invalid campus config", "error", err)
+		return nil, err
+	}
+
+	svc := service.New(repo, aiService, log, service.Options{
+		Admins:   cfg.Admins,
+		AILimits: aiLimits(cfg),
+		// Без ключа OpenAI разбор через AI всегда завершался бы ошибкой
+		AILessonParsing:     cfg.AI.LessonParserFallback && cfg.OpenAIKey != "",
+		BellSchedules:       bells,
+		DefaultBellSchedule: cfg.Bells.Default,
+		Location:            location,
+		Holidays:            holidays,
+		Campus:              campus,
+	})
 
-	tgBot, err := bot.New(cfg.Telegram.Token, bot.WithDefaultHandler(defaultHandler(log)))
+	var webhookPath string
+	switch cfg.Telegram.Mode {
+	case "", modePolling:
+	case modeWebhook:
+		webhookPath, err = telegramWebhookPath(cfg.Telegram.Webhook)
+		if err != nil {
+			log.Error("Invalid webhook config", "error", err)
+			return nil, err
+		}
+	default:
+		err = fmt.Errorf("unknown telegram mode %q", cfg.Telegram.Mode)
+		log.Error("Invalid telegram config", "error", err)
+		return nil, err
+	}
+
+	handlers := newInflight()
+
+	opts := []bot.Option{
+		bot.WithHTTPClient(pollTimeout, telegram.NewHTTPClient(pollTimeout)),
+		bot.WithDefaultHandler(defaultHandler(log)),
+		bot.WithMiddlewares(
+			handlers.middleware,
+			telegram.MetricsMiddleware(m),
+			telegram.LoggingMiddleware(log),
+			telegram.RecoverMiddleware(svc, log),
+			telegram.RegisterUserMiddleware(svc, log),
+		),
+	}
+	if cfg.Telegram.Webhook.SecretToken != "" {
+		opts = append(opts, bot.WithWebhookSecretToken(cfg.Telegram.Webhook.SecretToken))
+	}
+
+	tgBot, err := bot.New(cfg.Telegram.Token, opts...)
 	if err != nil {
 		log.Error("Failed to create bot", "error", err)
 		return nil, err
 	}
 
 	telegram.RegisterHandlers(tgBot, svc, log)
+	telegram.RegisterSessionMetrics(m)
+
+	shutdownTimeout := cfg.ShutdownTimeout
+	if shutdownTimeout <= 0 {
+		shutdownTimeout = defaultShutdownTimeout
+	}
 
 	log.Info("Bot initialized successfully")
 	return &Bot{
-		tgBot:   tgBot,
-		service: svc,
-		logger:  log,
+		tgBot:           tgBot,
+		service:         svc,
+		repo:            repo,
+		logger:          log,
+		inflight:        handlers,
+		shutdownTimeout: shutdownTimeout,
+		webhook:         cfg.Telegram.Webhook,
+		webhookPath:     webhookPath,
+		metrics:         m,
+		adminListen:     cfg.Admin.Listen,
+		adminToken:      cfg.Admin.Token,
+		configLogLevel:  cfg.LogLevel,
+		hasOpenAIKey:    cfg.OpenAIKey != "",
 	}, nil
 }
 
+// ApplyConfig применяет настройки, которые можно менять без перезапуска:
+// уровень логирования, параметры модели, лимиты AI и разбор пар через AI.
+// Уровень логирования применяется, только если log_level в конфиге изменился:
+// уровень, заданный через /loglevel, не сбрасывается при каждой перезагрузке.
+func (b *Bot) ApplyConfig(cfg *config.Config) {
+	if cfg.LogLevel != b.configLogLevel {
+		if err := b.logger.SetLevel(cfg.LogLevel); err != nil {
+			b.logger.Errorw("Failed to set log level", "error", err, "level", cfg.LogLevel)
+		} else {
+			b.configLogLevel = cfg.LogLevel
+		}
+	}
+	b.service.SetAIParams(aiParams(cfg))
+	b.service.SetAILimits(aiLimits(cfg))
+	b.service.SetAILessonParsing(cfg.AI.LessonParserFallback && b.hasOpenAIKey)
+}
+
+// aiParams переводит параметры модели из конфига в формат сервиса
+func aiParams(cfg *config.Config) service.AIParams {
+	return service.AIParams{
+		Model:       cfg.AI.Model,
+		Temperature: cfg.AI.Temperature,
+		MaxTokens:   cfg.AI.MaxTokens,
+	}
+}
+
+// aiLimits переводит лимиты из конфига в формат сервиса
+func aiLimits(cfg *config.Config) map[domain.Role]service.Limits {
+	limits := make(map[domain.Role]service.Limits, len(cfg.AI.Limits))
+	for role, l := range cfg.AI.Limits {
+		limits[domain.Role(role)] = service.Limits{
+			RatePerMinute: l.RatePerMinute,
+			Burst:         l.Burst,
+			Daily:         l.Daily,
+			Monthly:       l.Monthly,
+		}
+	}
+	return limits
+}
+
+// bellSchedules разбирает расписания звонков из конфига
+func bellSchedules(cfg *config.Config) (map[string]domain.BellSchedule, error) {
+	bells := make(map[string]domain.BellSchedule, len(cfg.Bells.Schedules))
+	for name, slots := range cfg.Bells.Schedules {
+		bs, err := service.ParseBellSchedule(name, slots)
+		if err != nil {
+			return nil, err
+		}
+		bells[name] = bs
+	}
+	return bells, nil
+}
+
+// holidayDates проверяет даты праздников из конфига
+func holidayDates(cfg *config.Config) (map[string]string, error) {
+	holidays := make(map[string]string, len(cfg.Holidays))
+	for _, h := range cfg.Holidays {
+		date, err := time.Parse("2006-01-02", h.Date)
+		if err != nil {
+			return nil, fmt.Errorf("holiday %q: %w", h.Name, err)
+		}
+		holidays[date.Format("2006-01-02")] = h.Name
+	}
+	return holidays, nil
+}
+
+// campusDirectory собирает справочник аудиторий из конфига
+func campusDirectory(cfg *config.Config) (service.Campus, error) {
+	buildings := make(map[string]domain.Building, len(cfg.Campus.Buildings))
+	for _, bc := range cfg.Campus.Buildings {
+		buildings[bc.Code] = domain.Building{
+			Code:      bc.Code,
+			Name:      bc.Name,
+			Address:   bc.Address,
+			Latitude:  bc.Latitude,
+			Longitude: bc.Longitude,
+		}
+	}
+
+	campus := service.Campus{
+		Rooms:        make(map[string]domain.Room, len(cfg.Campus.Rooms)),
+		TransferTime: cfg.Campus.TransferTime,
+	}
+	for _, rc := range cfg.Campus.Rooms {
+		building, ok := buildings[rc.Building]
+		if !ok {
+			return service.Campus{}, fmt.Errorf("room %q: unknown building %q", rc.Code, rc.Building)
+		}
+		campus.Rooms[service.NormalizeRoomCode(rc.Code)] = domain.Room{
+			Code:     rc.Code,
+			Building: building,
+			Floor:    rc.Floor,
+		}
+	}
+
+	return campus, nil
+}
+
 func defaultHandler(log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		if update.Message != nil {
 			log.Debugw("Received message",
 				"chatID", update.Message.Chat.ID,
@@ -52,8 +263,36 @@ func defaultHandler(log logger.Logger) bot.HandlerFunc {
 	}
 }
 
-func (b *Bot) Run() error {
+// Run получает апдейты (long polling или вебхук), пока не отменен ctx, затем дожидается
+// выполняющихся обработчиков (не дольше shutdown_timeout) и закрывает репозиторий
+func (b *Bot) Run(ctx context.Context) error {
 	b.logger.Info("Starting bot...")
-	b.tgBot.Start(context.Background())
-	return nil
+
+	if b.adminListen != "" {
+		stopAdmin := b.startAdmin()
+		defer stopAdmin()
+	}
+
+	var runErr error
+	if b.webhookPath != "" {
+		runErr = b.runWebhook(ctx)
+	} else {
+		b.runPolling(ctx)
+	}
+
+	b.logger.Infow("Shutting down, waiting for in-flight handlers", "timeout", b.shutdownTimeout)
+	b.inflight.close()
+	if !b.inflight.wait(b.shutdownTimeout) {
+		b.logger.Warnw("Handlers did not finish in time, cancelling them", "timeout", b.shutdownTimeout)
+	}
+	b.inflight.cancelWork()
+
+	if closer, ok := b.repo.(io.Closer); ok {
+		if err := closer.Close(); err != nil {
+			return fmt.Errorf("close repository: %w", err)
+		}
+	}
+
+	b.logger.Info("Bot stopped")
+	return runErr
 }
@@ -0,0 +1,80 @@
+package app
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/go-telegram/bot"
+)
+
+// fakeBotAPI - поддельный Telegram Bot API: отвечает на методы заданным JSON
+// и запоминает, какие методы вызывались
+type fakeBotAPI struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	calls     []string
+	responses map[string]string
+	status    map[string]int
+}
+
+func newFakeBotAPI(t *testing.T) *fakeBotAPI {
+	t.Helper()
+	f := &fakeBotAPI{
+		responses: map[string]string{
+			"getMe": `{"ok":true,"result":{"id":1,"is_bot":true,"first_name":"test","username":"test_bot"}}`,
+		},
+		status: map[string]int{},
+	}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		f.mu.Lock()
+		f.calls = append(f.calls, method)
+		body, ok := f.responses[method]
+		status := f.status[method]
+		f.mu.Unlock()
+
+		if !ok {
+			body = `{"ok":true,"result":true}`
+		}
+		if status != 0 {
+			w.WriteHeader(status)
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// respond задает ответ на метод
+func (f *fakeBotAPI) respond(method string, status int, body string) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.status[method] = status
+	f.responses[method] = body
+}
+
+func (f *fakeBotAPI) called(method string) bool {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for _, c := range f.calls {
+		if c == method {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *fakeBotAPI) bot(t *testing.T, opts ...bot.Option) *bot.Bot {
+	t.Helper()
+	opts = append([]bot.Option{bot.WithServerURL(f.URL), bot.WithSkipGetMe()}, opts...)
+	b, err := bot.New("1:test", opts...)
+	if err != nil {
+		t.Fatalf("bot.New: %v", err)
+	}
+	return b
+}
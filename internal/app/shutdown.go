@@ -0,0 +1,75 @@
+package app
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// defaultShutdownTimeout - сколько ждать завершения обработчиков, если в конфиге не задано
+const defaultShutdownTimeout = 10 * time.Second
+
+// inflight отслеживает выполняющиеся обработчики, чтобы при остановке дождаться их.
+// После close новые апдейты не обрабатываются.
+type inflight struct {
+	mu     sync.RWMutex
+	wg     sync.WaitGroup
+	closed bool
+
+	// work живет дольше корневого контекста: обработчики продолжают работу
+	// после сигнала остановки и отменяются только по истечении таймаута
+	work       context.Context
+	cancelWork context.CancelFunc
+}
+
+func newInflight() *inflight {
+	work, cancel := context.WithCancel(context.Background())
+	return &inflight{work: work, cancelWork: cancel}
+}
+
+// middleware учитывает обработчик и отвязывает его контекст от отмены корневого
+func (f *inflight) middleware(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		f.mu.RLock()
+		if f.closed {
+			f.mu.RUnlock()
+			return
+		}
+		f.wg.Add(1)
+		f.mu.RUnlock()
+		defer f.wg.Done()
+
+		ctx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+		defer cancel()
+		stop := context.AfterFunc(f.work, cancel)
+		defer stop()
+
+		next(ctx, b, update)
+	}
+}
+
+// close запрещает обработку новых апдейтов
+func (f *inflight) close() {
+	f.mu.Lock()
+	f.closed = true
+	f.mu.Unlock()
+}
+
+// wait ждет завершения обработчиков не дольше timeout. Возвращает false при таймауте.
+func (f *inflight) wait(timeout time.Duration) bool {
+	done := make(chan struct{})
+	go func() {
+		f.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return true
+	case <-time.After(timeout):
+		return false
+	}
+}
@@ -0,0 +1,85 @@
+package app
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+func TestInflightWait(t *testing.T) {
+	tests := []struct {
+		name    string
+		work    time.Duration // сколько работает обработчик
+		timeout time.Duration
+		want    bool
+	}{
+		{name: "finishes in time", work: 50 * time.Millisecond, timeout: time.Second, want: true},
+		{name: "times out", work: time.Second, timeout: 50 * time.Millisecond, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := newInflight()
+			defer f.cancelWork()
+
+			started := make(chan struct{})
+			var finished, cancelled atomic.Bool
+			handler := f.middleware(func(ctx context.Context, _ *bot.Bot, _ *models.Update) {
+				close(started)
+				select {
+				case <-time.After(tt.work):
+					finished.Store(true)
+				case <-ctx.Done():
+					cancelled.Store(true)
+				}
+			})
+
+			// Корневой контекст отменяется сигналом остановки сразу после старта обработчика
+			root, stop := context.WithCancel(context.Background())
+			done := make(chan struct{})
+			go func() {
+				defer close(done)
+				handler(root, nil, &models.Update{})
+			}()
+			<-started
+			stop()
+
+			f.close()
+			if got := f.wait(tt.timeout); got != tt.want {
+				t.Fatalf("wait = %v, want %v", got, tt.want)
+			}
+			if tt.want && (!finished.Load() || cancelled.Load()) {
+				t.Error("handler was cancelled by the root context instead of finishing")
+			}
+
+			f.cancelWork()
+			<-done
+			if !tt.want && !cancelled.Load() {
+				t.Error("cancelWork did not cancel the handler after the timeout")
+			}
+		})
+	}
+}
+
+func TestInflightRejectsAfterClose(t *testing.T) {
+	f := newInflight()
+	defer f.cancelWork()
+
+	var calls atomic.Int32
+	handler := f.middleware(func(context.Context, *bot.Bot, *models.Update) { calls.Add(1) })
+
+	handler(context.Background(), nil, &models.Update{})
+	f.close()
+	handler(context.Background(), nil, &models.Update{})
+
+	if n := calls.Load(); n != 1 {
+		t.Errorf("handler called %d times, want only the update before close", n)
+	}
+	if !f.wait(time.Second) {
+		t.Error("wait timed out with no handlers running")
+	}
+}
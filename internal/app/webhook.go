@@ -0,0 +1,141 @@
+package app
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/polyk005/tg_bot/internal/config"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+const (
+	modePolling = "polling"
+	modeWebhook = "webhook"
+)
+
+// telegramWebhookPath проверяет настройки вебхука и возвращает путь, на котором его слушать
+func telegramWebhookPath(cfg config.WebhookConfig) (string, error) {
+	if cfg.Listen == "" {
+		return "", errors.New("webhook: listen address is required")
+	}
+	if (cfg.TLSCert == "") != (cfg.TLSKey == "") {
+		return "", errors.New("webhook: tls_cert and tls_key must be set together")
+	}
+
+	u, err := url.Parse(cfg.URL)
+	if err != nil || u.Scheme != "https" || u.Host == "" {
+		return "", fmt.Errorf("webhook: url must be an absolute https URL, got %q", cfg.URL)
+	}
+
+	if u.Path == "" {
+		return "/", nil
+	}
+	return u.Path, nil
+}
+
+// runPolling получает апдейты long polling'ом до отмены ctx.
+// Оставшийся от webhook-режима вебхук мешает getUpdates, поэтому он удаляется.
+func (b *Bot) runPolling(ctx context.Context) {
+	if _, err := b.tgBot.DeleteWebhook(ctx, &bot.DeleteWebhookParams{}); err != nil {
+		b.logger.Errorw("Failed to delete webhook", "error", err)
+	}
+	b.tgBot.Start(ctx)
+}
+
+// runWebhook регистрирует вебхук и принимает апдейты встроенным HTTP-сервером до отмены ctx,
+// затем останавливает сервер и удаляет вебхук
+func (b *Bot) runWebhook(ctx context.Context) error {
+	cfg := b.webhook
+
+	mux := http.NewServeMux()
+	mux.Handle(b.webhookPath, secretTokenCheck(cfg.SecretToken, b.tgBot.WebhookHandler(), b.logger))
+
+	server := &http.Server{
+		Addr:              cfg.Listen,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	serveErr := make(chan error, 1)
+	go func() {
+		var err error
+		if cfg.TLSCert != "" {
+			err = server.ListenAndServeTLS(cfg.TLSCert, cfg.TLSKey)
+		} else {
+			err = server.ListenAndServe()
+		}
+		if !errors.Is(err, http.ErrServerClosed) {
+			serveErr <- err
+		}
+	}()
+
+	if _, err := b.tgBot.SetWebhook(ctx, &bot.SetWebhookParams{
+		URL:         cfg.URL,
+		SecretToken: cfg.SecretToken,
+	}); err != nil {
+		server.Close()
+		return fmt.Errorf("set webhook: %w", err)
+	}
+	b.logger.Infow("Webhook registered", "url", cfg.URL, "listen", cfg.Listen)
+
+	workers, stopWorkers := context.WithCancel(ctx)
+	defer stopWorkers()
+
+	done := make(chan struct{})
+	go func() {
+		b.tgBot.StartWebhook(workers)
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-ctx.Done():
+	case err = <-serveErr:
+		err = fmt.Errorf("webhook server: %w", err)
+	}
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), b.shutdownTimeout)
+	defer cancel()
+
+	if shutdownErr := server.Shutdown(shutdownCtx); shutdownErr != nil {
+		b.logger.Errorw("Failed to stop webhook server", "error", shutdownErr)
+		server.Close()
+	}
+	stopWorkers()
+	<-done
+
+	// Отдельный таймаут: зависшие соединения не должны помешать удалить вебхук
+	deleteCtx, cancelDelete := context.WithTimeout(context.Background(), b.shutdownTimeout)
+	defer cancelDelete()
+
+	if _, deleteErr := b.tgBot.DeleteWebhook(deleteCtx, &bot.DeleteWebhookParams{}); deleteErr != nil {
+		b.logger.Errorw("Failed to delete webhook", "error", deleteErr)
+	}
+
+	return err
+}
+
+// secretTokenCheck отклоняет запросы без правильного X-Telegram-Bot-Api-Secret-Token
+func secretTokenCheck(secret string, next http.Handler, log logger.Logger) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+
+		token := r.Header.Get("X-Telegram-Bot-Api-Secret-Token")
+		if secret != "" && subtle.ConstantTimeCompare([]byte(token), []byte(secret)) != 1 {
+			log.Warnw("Rejected webhook request with invalid secret token", "remote", r.RemoteAddr)
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
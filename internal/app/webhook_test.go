@@ -0,0 +1,185 @@
+package app
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/config"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+func TestSecretTokenCheck(t *testing.T) {
+	tests := []struct {
+		name     string
+		method   string
+		token    string
+		wantCode int
+		wantNext bool
+	}{
+		{name: "valid", method: http.MethodPost, token: "s3cret", wantCode: http.StatusOK, wantNext: true},
+		{name: "missing token", method: http.MethodPost, wantCode: http.StatusForbidden},
+		{name: "wrong token", method: http.MethodPost, token: "guess", wantCode: http.StatusForbidden},
+		{name: "get", method: http.MethodGet, token: "s3cret", wantCode: http.StatusMethodNotAllowed},
+		{name: "put", method: http.MethodPut, token: "s3cret", wantCode: http.StatusMethodNotAllowed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			called := false
+			next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { called = true })
+			h := secretTokenCheck("s3cret", next, logger.New("error"))
+
+			req := httptest.NewRequest(tt.method, "/hook", strings.NewReader(`{"update_id":1}`))
+			if tt.token != "" {
+				req.Header.Set("X-Telegram-Bot-Api-Secret-Token", tt.token)
+			}
+			rec := httptest.NewRecorder()
+			h.ServeHTTP(rec, req)
+
+			if rec.Code != tt.wantCode {
+				t.Errorf("status = %d, want %d", rec.Code, tt.wantCode)
+			}
+			if called != tt.wantNext {
+				t.Errorf("next called = %v, want %v", called, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestTelegramWebhookPath(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     config.WebhookConfig
+		want    string
+		wantErr bool
+	}{
+		{name: "path", cfg: config.WebhookConfig{Listen: ":8443", URL: "https://example.com/tg/hook"}, want: "/tg/hook"},
+		{name: "root", cfg: config.WebhookConfig{Listen: ":8443", URL: "https://example.com"}, want: "/"},
+		{name: "no listen", cfg: config.WebhookConfig{URL: "https://example.com/hook"}, wantErr: true},
+		{name: "http", cfg: config.WebhookConfig{Listen: ":8443", URL: "http://example.com/hook"}, wantErr: true},
+		{name: "half tls", cfg: config.WebhookConfig{Listen: ":8443", URL: "https://example.com/hook", TLSCert: "cert.pem"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := telegramWebhookPath(tt.cfg)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("path = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+// freeAddr возвращает свободный локальный адрес для HTTP-сервера
+func freeAddr(t *testing.T) string {
+	t.Helper()
+	l, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	addr := l.Addr().String()
+	l.Close()
+	return addr
+}
+
+func TestRunWebhook(t *testing.T) {
+	api := newFakeBotAPI(t)
+	updates := make(chan int64, 1)
+	tgBot := api.bot(t, bot.WithDefaultHandler(func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		updates <- update.ID
+	}))
+
+	addr := freeAddr(t)
+	b := &Bot{
+		tgBot:           tgBot,
+		logger:          logger.New("error"),
+		shutdownTimeout: time.Second,
+		webhook:         config.WebhookConfig{Listen: addr, URL: "https://example.com/hook", SecretToken: "s3cret"},
+		webhookPath:     "/hook",
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	errc := make(chan error, 1)
+	go func() { errc <- b.runWebhook(ctx) }()
+
+	post := func(token string) *http.Response {
+		t.Helper()
+		req, _ := http.NewRequest(http.MethodPost, "http://"+addr+"/hook", strings.NewReader(`{"update_id":42,"message":{"message_id":1,"chat":{"id":7},"text":"hi"}}`))
+		req.Header.Set("X-Telegram-Bot-Api-Secret-Token", token)
+		deadline := time.Now().Add(2 * time.Second)
+		for {
+			resp, err := http.DefaultClient.Do(req)
+			if err == nil {
+				resp.Body.Close()
+				return resp
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("webhook server did not start: %v", err)
+			}
+			time.Sleep(10 * time.Millisecond)
+			req.Body, _ = req.GetBody()
+		}
+	}
+
+	if resp := post("guess"); resp.StatusCode != http.StatusForbidden {
+		t.Errorf("wrong token: status %d, want %d", resp.StatusCode, http.StatusForbidden)
+	}
+	if resp := post("s3cret"); resp.StatusCode != http.StatusOK {
+		t.Errorf("valid request: status %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+
+	select {
+	case id := <-updates:
+		if id != 42 {
+			t.Errorf("dispatched update %d, want 42", id)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("valid update was not dispatched")
+	}
+
+	if !api.called("setWebhook") {
+		t.Error("setWebhook was not called")
+	}
+
+	cancel()
+	select {
+	case err := <-errc:
+		if err != nil {
+			t.Fatalf("runWebhook: %v", err)
+		}
+	case <-time.After(3 * time.Second):
+		t.Fatal("runWebhook did not stop after cancel")
+	}
+
+	if !api.called("deleteWebhook") {
+		t.Error("deleteWebhook was not called on shutdown")
+	}
+}
+
+func TestRunWebhookSetWebhookFails(t *testing.T) {
+	api := newFakeBotAPI(t)
+	api.respond("setWebhook", http.StatusBadRequest, `{"ok":false,"error_code":400,"description":"Bad Request: bad webhook"}`)
+
+	b := &Bot{
+		tgBot:           api.bot(t),
+		logger:          logger.New("error"),
+		shutdownTimeout: time.Second,
+		webhook:         config.WebhookConfig{Listen: freeAddr(t), URL: "https://example.com/hook"},
+		webhookPath:     "/hook",
+	}
+
+	if err := b.runWebhook(context.Background()); err == nil || !strings.Contains(err.Error(), "set webhook") {
+		t.Fatalf("runWebhook err = %v, want set webhook error", err)
+	}
+}
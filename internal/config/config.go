@@ -1,7 +1,10 @@
 package config
 
 import (
+	"errors"
+	"fmt"
 	"os"
+	"time"
 
 	"gopkg.in/yaml.v2"
 )
@@ -9,24 +12,185 @@ import (
 type Config struct {
 	Telegram struct {
 		Token string `yaml:"token"`
+		// Mode - способ получения апдейтов: polling (по умолчанию) или webhook
+		Mode    string        `yaml:"mode"`
+		Webhook WebhookConfig `yaml:"webhook"`
 	} `yaml:"telegram"`
 	Database struct {
 		DSN string `yaml:"dsn"`
 	} `yaml:"database"`
-	LogLevel  string `yaml:"log_level"`
-	OpenAIKey string `yaml:"openai_key"`
+	LogLevel string `yaml:"log_level"`
+	// ShutdownTimeout - сколько ждать завершения обработчиков при остановке
+	ShutdownTimeout time.Duration `yaml:"shutdown_timeout"`
+	// ReloadInterval - как часто проверять изменения файла конфига, 0 - только по SIGHUP
+	ReloadInterval time.Duration `yaml:"reload_interval"`
+	OpenAIKey      string        `yaml:"openai_key"`
+	Admin          struct {
+		// Listen - адрес HTTP-сервера с /healthz, /readyz и /metrics. Пусто - сервер выключен
+		Listen string `yaml:"listen"`
+		// Token разрешает менять уровень логирования через /loglevel (заголовок
+		// Authorization: Bearer <token>). Без токена изменения принимаются только с localhost
+		Token string `yaml:"token"`
+	} `yaml:"admin"`
+	Admins []int64 `yaml:"admins"`
+	// TimeZone - часовой пояс по умолчанию (IANA), пользователь может выбрать свой
+	TimeZone string `yaml:"timezone"`
+	AI       struct {
+		// Limits задает лимиты AI-запросов по ролям (user, admin)
+		Limits map[string]LimitConfig `yaml:"limits"`
+		// LessonParserFallback разрешает разбирать пары через AI
+		LessonParserFallback bool `yaml:"lesson_parser_fallback"`
+		// Model, Temperature и MaxTokens - параметры модели для /ask и разбора пар
+		Model       string  `yaml:"model"`
+		Temperature float32 `yaml:"temperature"`
+		MaxTokens   int     `yaml:"max_tokens"`
+	} `yaml:"ai"`
+	Bells struct {
+		// Default - расписание звонков для пользователей, не выбравших свое
+		Default string `yaml:"default"`
+		// Schedules - интервалы пар ("08:30-10:00") по названиям учебных заведений или групп
+		Schedules map[string][]string `yaml:"schedules"`
+	} `yaml:"bells"`
+	// Holidays - праздничные дни без пар
+	Holidays []Holiday `yaml:"holidays"`
+	Campus   struct {
+		Buildings []BuildingConfig `yaml:"buildings"`
+		Rooms     []RoomConfig     `yaml:"rooms"`
+		// TransferTime - минимальный перерыв для перехода между корпусами
+		TransferTime time.Duration `yaml:"transfer_time"`
+	} `yaml:"campus"`
+}
+
+// WebhookConfig описывает прием апдейтов через вебхук
+type WebhookConfig struct {
+	// Listen - адрес встроенного HTTP-сервера, например ":8080"
+	Listen string `yaml:"listen"`
+	// URL - публичный адрес вебхука, путь из него используется и локально
+	URL string `yaml:"url"`
+	// SecretToken сверяется с заголовком X-Telegram-Bot-Api-Secret-Token
+	SecretToken string `yaml:"secret_token"`
+	// TLSCert и TLSKey включают HTTPS без обратного прокси
+	TLSCert string `yaml:"tls_cert"`
+	TLSKey  string `yaml:"tls_key"`
+}
+
+// BuildingConfig описывает корпус
+type BuildingConfig struct {
+	Code      string  `yaml:"code"`
+	Name      string  `yaml:"name"`
+	Address   string  `yaml:"address"`
+	Latitude  float64 `yaml:"lat"`
+	Longitude float64 `yaml:"lon"`
+}
+
+// RoomConfig описывает аудиторию
+type RoomConfig struct {
+	Code     string `yaml:"code"`
+	Building string `yaml:"building"`
+	Floor    int    `yaml:"floor"`
+}
+
+// Holiday описывает один выходной день
+type Holiday struct {
+	Date string `yaml:"date"` // 2006-01-02
+	Name string `yaml:"name"`
 }
 
+// LimitConfig описывает лимиты AI-запросов для роли. 0 - без ограничений
+type LimitConfig struct {
+	RatePerMinute int `yaml:"rate_per_minute"`
+	Burst         int `yaml:"burst"`
+	Daily         int `yaml:"daily"`
+	Monthly       int `yaml:"monthly"`
+}
+
+// ConfigPathEnv - переменная окружения с путем к файлу конфига
+const ConfigPathEnv = "TG_BOT_CONFIG"
+
+// DefaultPath возвращает путь к конфигу из TG_BOT_CONFIG или config.yaml
+func DefaultPath() string {
+	if path := os.Getenv(ConfigPathEnv); path != "" {
+		return path
+	}
+	return "config.yaml"
+}
+
+// Load собирает конфиг по слоям: значения по умолчанию, файл path, переменные окружения
+// (см. EnvPrefix), и проверяет результат. При пустом path файл не читается.
 func Load(path string) (*Config, error) {
-	file, err := os.ReadFile(path)
-	if err != nil {
+	cfg := defaults()
+
+	if path != "" {
+		file, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := yaml.Unmarshal(file, cfg); err != nil {
+			return nil, fmt.Errorf("parse %s: %w", path, err)
+		}
+	}
+
+	if err := applyEnv(cfg); err != nil {
 		return nil, err
 	}
 
-	var cfg Config
-	if err := yaml.Unmarshal(file, &cfg); err != nil {
+	if err := cfg.Validate(); err != nil {
 		return nil, err
 	}
 
-	return &cfg, nil
+	return cfg, nil
+}
+
+// defaults возвращает значения, которые действуют, если их нет ни в файле, ни в окружении
+func defaults() *Config {
+	cfg := &Config{
+		LogLevel:        "info",
+		ShutdownTimeout: 10 * time.Second,
+	}
+	cfg.Telegram.Mode = "polling"
+	return cfg
+}
+
+// Validate проверяет обязательные поля и допустимые значения
+func (c *Config) Validate() error {
+	var errs []error
+
+	if c.Telegram.Token == "" {
+		errs = append(errs, fmt.Errorf("telegram.token is required (set %sTELEGRAM_TOKEN or %sTELEGRAM_TOKEN_FILE)", EnvPrefix, EnvPrefix))
+	}
+	if c.Database.DSN == "" {
+		errs = append(errs, fmt.Errorf("database.dsn is required (set %sDATABASE_DSN or %sDATABASE_DSN_FILE)", EnvPrefix, EnvPrefix))
+	}
+	switch c.Telegram.Mode {
+	case "polling", "webhook":
+	default:
+		errs = append(errs, fmt.Errorf("telegram.mode must be polling or webhook, got %q", c.Telegram.Mode))
+	}
+	switch c.LogLevel {
+	case "debug", "info", "warn", "error":
+	default:
+		errs = append(errs, fmt.Errorf("log_level must be debug, info, warn or error, got %q", c.LogLevel))
+	}
+	if c.ShutdownTimeout < 0 {
+		errs = append(errs, fmt.Errorf("shutdown_timeout must not be negative, got %s", c.ShutdownTimeout))
+	}
+	if c.ReloadInterval < 0 {
+		errs = append(errs, fmt.Errorf("reload_interval must not be negative, got %s", c.ReloadInterval))
+	}
+	if c.AI.Temperature < 0 || c.AI.Temperature > 2 {
+		errs = append(errs, fmt.Errorf("ai.temperature must be between 0 and 2, got %g", c.AI.Temperature))
+	}
+	if c.AI.MaxTokens < 0 {
+		errs = append(errs, fmt.Errorf("ai.max_tokens must not be negative, got %d", c.AI.MaxTokens))
+	}
+	for role, l := range c.AI.Limits {
+		if l.RatePerMinute < 0 || l.Burst < 0 || l.Daily < 0 || l.Monthly < 0 {
+			errs = append(errs, fmt.Errorf("ai.limits.%s: limits must not be negative", role))
+		}
+	}
+
+	if len(errs) > 0 {
+		return fmt.Errorf("invalid config: %w", errors.Join(errs...))
+	}
+	return nil
 }
@@ -0,0 +1,275 @@
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+// writeFile записывает содержимое во временный файл и возвращает путь к нему
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return path
+}
+
+const minimalYAML = `
+telegram:
+  token: file-token
+database:
+  dsn: file-dsn
+`
+
+func TestLoadDefaults(t *testing.T) {
+	cfg, err := Load(writeFile(t, "config.yaml", minimalYAML))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.LogLevel != "info" || cfg.Telegram.Mode != "polling" || cfg.ShutdownTimeout != 10*time.Second {
+		t.Errorf("defaults not applied: log_level=%q mode=%q shutdown_timeout=%s",
+			cfg.LogLevel, cfg.Telegram.Mode, cfg.ShutdownTimeout)
+	}
+	if cfg.Telegram.Token != "file-token" || cfg.Database.DSN != "file-dsn" {
+		t.Errorf("file values not applied: token=%q dsn=%q", cfg.Telegram.Token, cfg.Database.DSN)
+	}
+}
+
+func TestLoadEnvOverrides(t *testing.T) {
+	t.Setenv("TG_BOT_TELEGRAM_TOKEN", "env-token")
+	t.Setenv("TG_BOT_LOG_LEVEL", "debug")
+	t.Setenv("TG_BOT_SHUTDOWN_TIMEOUT", "3s")
+	t.Setenv("TG_BOT_ADMINS", "1, 2,3")
+	t.Setenv("TG_BOT_AI_TEMPERATURE", "0.5")
+	t.Setenv("TG_BOT_AI_LESSON_PARSER_FALLBACK", "true")
+	t.Setenv("TG_BOT_TELEGRAM_WEBHOOK_LISTEN", ":8443")
+
+	cfg, err := Load(writeFile(t, "config.yaml", minimalYAML))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.Telegram.Token != "env-token" {
+		t.Errorf("token = %q, env must win over the file", cfg.Telegram.Token)
+	}
+	if cfg.Database.DSN != "file-dsn" {
+		t.Errorf("dsn = %q, file value must stay without env", cfg.Database.DSN)
+	}
+	if cfg.LogLevel != "debug" || cfg.ShutdownTimeout != 3*time.Second {
+		t.Errorf("log_level=%q shutdown_timeout=%s", cfg.LogLevel, cfg.ShutdownTimeout)
+	}
+	if !reflect.DeepEqual(cfg.Admins, []int64{1, 2, 3}) {
+		t.Errorf("admins = %v", cfg.Admins)
+	}
+	if cfg.AI.Temperature != 0.5 || !cfg.AI.LessonParserFallback {
+		t.Errorf("ai.temperature=%g ai.lesson_parser_fallback=%v", cfg.AI.Temperature, cfg.AI.LessonParserFallback)
+	}
+	if cfg.Telegram.Webhook.Listen != ":8443" {
+		t.Errorf("telegram.webhook.listen = %q", cfg.Telegram.Webhook.Listen)
+	}
+}
+
+func TestLoadSecretFiles(t *testing.T) {
+	t.Setenv("TG_BOT_TELEGRAM_TOKEN_FILE", writeFile(t, "token", "secret-token\n"))
+	t.Setenv("TG_BOT_DATABASE_DSN_FILE", writeFile(t, "dsn", "postgres://db \r\n"))
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Telegram.Token != "secret-token" {
+		t.Errorf("token = %q, trailing newline must be trimmed", cfg.Telegram.Token)
+	}
+	if cfg.Database.DSN != "postgres://db" {
+		t.Errorf("dsn = %q", cfg.Database.DSN)
+	}
+}
+
+func TestLoadVariableWinsOverFile(t *testing.T) {
+	t.Setenv("TG_BOT_TELEGRAM_TOKEN", "plain")
+	t.Setenv("TG_BOT_TELEGRAM_TOKEN_FILE", writeFile(t, "token", "from-file"))
+	t.Setenv("TG_BOT_DATABASE_DSN", "dsn")
+
+	cfg, err := Load("")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Telegram.Token != "plain" {
+		t.Errorf("token = %q, want the variable over the _FILE variant", cfg.Telegram.Token)
+	}
+}
+
+func TestLoadMissingSecretFile(t *testing.T) {
+	t.Setenv("TG_BOT_TELEGRAM_TOKEN_FILE", filepath.Join(t.TempDir(), "missing"))
+	t.Setenv("TG_BOT_DATABASE_DSN", "dsn")
+
+	_, err := Load("")
+	if err == nil || !strings.Contains(err.Error(), "TG_BOT_TELEGRAM_TOKEN_FILE") {
+		t.Fatalf("err = %v, want an error naming the _FILE variable", err)
+	}
+}
+
+func TestLoadOpenAIKeyAlias(t *testing.T) {
+	t.Setenv("TG_BOT_TELEGRAM_TOKEN", "token")
+	t.Setenv("TG_BOT_DATABASE_DSN", "dsn")
+
+	t.Run("alias", func(t *testing.T) {
+		t.Setenv("OPENAI_KEY", "alias-key")
+		cfg, err := Load("")
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.OpenAIKey != "alias-key" {
+			t.Errorf("openai_key = %q", cfg.OpenAIKey)
+		}
+	})
+
+	t.Run("alias file", func(t *testing.T) {
+		t.Setenv("OPENAI_KEY_FILE", writeFile(t, "key", "file-key\n"))
+		cfg, err := Load("")
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.OpenAIKey != "file-key" {
+			t.Errorf("openai_key = %q", cfg.OpenAIKey)
+		}
+	})
+
+	t.Run("prefixed wins", func(t *testing.T) {
+		t.Setenv("OPENAI_KEY", "alias-key")
+		t.Setenv("TG_BOT_OPENAI_KEY", "prefixed-key")
+		cfg, err := Load("")
+		if err != nil {
+			t.Fatalf("Load: %v", err)
+		}
+		if cfg.OpenAIKey != "prefixed-key" {
+			t.Errorf("openai_key = %q", cfg.OpenAIKey)
+		}
+	})
+}
+
+func TestLoadInvalidEnvValue(t *testing.T) {
+	t.Setenv("TG_BOT_SHUTDOWN_TIMEOUT", "soon")
+
+	_, err := Load(writeFile(t, "config.yaml", minimalYAML))
+	if err == nil || !strings.Contains(err.Error(), "TG_BOT_SHUTDOWN_TIMEOUT") {
+		t.Fatalf("err = %v, want an error naming the variable", err)
+	}
+}
+
+func TestValidate(t *testing.T) {
+	valid := func() *Config {
+		cfg := defaults()
+		cfg.Telegram.Token = "token"
+		cfg.Database.DSN = "dsn"
+		return cfg
+	}
+
+	tests := []struct {
+		name    string
+		modify  func(*Config)
+		wantErr []string
+	}{
+		{name: "valid", modify: func(*Config) {}},
+		{
+			name:    "missing secrets",
+			modify:  func(c *Config) { c.Telegram.Token, c.Database.DSN = "", "" },
+			wantErr: []string{"telegram.token is required", "TG_BOT_TELEGRAM_TOKEN_FILE", "database.dsn is required"},
+		},
+		{name: "mode", modify: func(c *Config) { c.Telegram.Mode = "push" }, wantErr: []string{"telegram.mode"}},
+		{name: "log level", modify: func(c *Config) { c.LogLevel = "verbose" }, wantErr: []string{"log_level"}},
+		{name: "shutdown timeout", modify: func(c *Config) { c.ShutdownTimeout = -time.Second }, wantErr: []string{"shutdown_timeout"}},
+		{name: "reload interval", modify: func(c *Config) { c.ReloadInterval = -time.Second }, wantErr: []string{"reload_interval"}},
+		{name: "temperature", modify: func(c *Config) { c.AI.Temperature = 3 }, wantErr: []string{"ai.temperature"}},
+		{name: "max tokens", modify: func(c *Config) { c.AI.MaxTokens = -1 }, wantErr: []string{"ai.max_tokens"}},
+		{
+			name:    "limits",
+			modify:  func(c *Config) { c.AI.Limits = map[string]LimitConfig{"user": {Daily: -1}} },
+			wantErr: []string{"ai.limits.user"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := valid()
+			tt.modify(cfg)
+			err := cfg.Validate()
+
+			if len(tt.wantErr) == 0 {
+				if err != nil {
+					t.Fatalf("Validate: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatal("Validate returned nil")
+			}
+			for _, want := range tt.wantErr {
+				if !strings.Contains(err.Error(), want) {
+					t.Errorf("error %q does not mention %q", err, want)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadEnvStructuredOverrides(t *testing.T) {
+	t.Setenv("TG_BOT_AI_LIMITS", `{"user":{"rate_per_minute":2,"daily":20}}`)
+	t.Setenv("TG_BOT_BELLS_SCHEDULES", `{"college":["09:00-10:30","10:40-12:10"]}`)
+	t.Setenv("TG_BOT_HOLIDAYS", `[{"date":"2026-11-04","name":"День народного единства"}]`)
+	t.Setenv("TG_BOT_CAMPUS_BUILDINGS", `[{"code":"A","name":"Главный корпус","lat":55.7,"lon":37.6}]`)
+	t.Setenv("TG_BOT_CAMPUS_ROOMS", "- {code: A-101, building: A, floor: 1}")
+
+	cfg, err := Load(writeFile(t, "config.yaml", minimalYAML+`
+ai:
+  limits:
+    admin:
+      daily: 100
+bells:
+  schedules:
+    main: ["08:30-10:00"]
+`))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	wantLimits := map[string]LimitConfig{"user": {RatePerMinute: 2, Daily: 20}}
+	if !reflect.DeepEqual(cfg.AI.Limits, wantLimits) {
+		t.Errorf("ai.limits = %+v, want %+v replacing the file value", cfg.AI.Limits, wantLimits)
+	}
+	wantBells := map[string][]string{"college": {"09:00-10:30", "10:40-12:10"}}
+	if !reflect.DeepEqual(cfg.Bells.Schedules, wantBells) {
+		t.Errorf("bells.schedules = %v, want %v", cfg.Bells.Schedules, wantBells)
+	}
+	if want := []Holiday{{Date: "2026-11-04", Name: "День народного единства"}}; !reflect.DeepEqual(cfg.Holidays, want) {
+		t.Errorf("holidays = %+v, want %+v", cfg.Holidays, want)
+	}
+	if want := []BuildingConfig{{Code: "A", Name: "Главный корпус", Latitude: 55.7, Longitude: 37.6}}; !reflect.DeepEqual(cfg.Campus.Buildings, want) {
+		t.Errorf("campus.buildings = %+v, want %+v", cfg.Campus.Buildings, want)
+	}
+	if want := []RoomConfig{{Code: "A-101", Building: "A", Floor: 1}}; !reflect.DeepEqual(cfg.Campus.Rooms, want) {
+		t.Errorf("campus.rooms = %+v, want %+v", cfg.Campus.Rooms, want)
+	}
+}
+
+func TestLoadInvalidStructuredEnv(t *testing.T) {
+	tests := map[string]string{
+		"TG_BOT_AI_LIMITS": `{"user":{"daily":"many"}}`,
+		"TG_BOT_HOLIDAYS":  `[{"date":"2026-11-04","title":"typo"}]`,
+	}
+	for name, value := range tests {
+		t.Run(name, func(t *testing.T) {
+			t.Setenv(name, value)
+
+			_, err := Load(writeFile(t, "config.yaml", minimalYAML))
+			if err == nil || !strings.Contains(err.Error(), name) {
+				t.Fatalf("err = %v, want an error naming the variable", err)
+			}
+		})
+	}
+}
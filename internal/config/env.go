@@ -0,0 +1,172 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v2"
+)
+
+// EnvPrefix - префикс переменных окружения, переопределяющих поля конфига.
+// Имя переменной собирается из yaml-ключей: telegram.token -> TG_BOT_TELEGRAM_TOKEN.
+const EnvPrefix = "TG_BOT_"
+
+// fileSuffix - суффикс переменной с путем к файлу, из которого читается значение (секреты Docker/Kubernetes)
+const fileSuffix = "_FILE"
+
+// envAliases - общепринятые имена переменных, которые тоже учитываются
+var envAliases = map[string]string{
+	EnvPrefix + "OPENAI_KEY": "OPENAI_KEY",
+}
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// applyEnv переопределяет поля конфига значениями из переменных окружения.
+// Поддерживаются строки, числа, bool, time.Duration и списки через запятую.
+// Словари и списки структур (ai.limits, bells.schedules, holidays, campus.*)
+// задаются целиком в JSON или YAML и заменяют значение из файла:
+// TG_BOT_AI_LIMITS='{"user":{"daily":20}}'.
+func applyEnv(cfg *Config) error {
+	return applyEnvStruct(reflect.ValueOf(cfg).Elem(), EnvPrefix)
+}
+
+func applyEnvStruct(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		key, _, _ := strings.Cut(field.Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		name := prefix + strings.ToUpper(key)
+
+		fv := v.Field(i)
+		if fv.Kind() == reflect.Struct && fv.Type() != durationType {
+			if err := applyEnvStruct(fv, name+"_"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok, err := lookupEnv(name)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		if err := setField(fv, value); err != nil {
+			return fmt.Errorf("env %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// lookupEnv ищет значение в NAME, NAME_FILE и затем в псевдониме.
+// Значение из файла берется без завершающих пробелов и переводов строки.
+func lookupEnv(name string) (string, bool, error) {
+	names := []string{name}
+	if alias, ok := envAliases[name]; ok {
+		names = append(names, alias)
+	}
+
+	for _, n := range names {
+		if value, ok := os.LookupEnv(n); ok {
+			return value, true, nil
+		}
+		if path, ok := os.LookupEnv(n + fileSuffix); ok {
+			data, err := os.ReadFile(path)
+			if err != nil {
+				return "", false, fmt.Errorf("env %s%s: %w", n, fileSuffix, err)
+			}
+			return strings.TrimRight(string(data), " \t\r\n"), true, nil
+		}
+	}
+	return "", false, nil
+}
+
+// setField записывает строковое значение в поле подходящего типа
+func setField(fv reflect.Value, value string) error {
+	if fv.Type() == durationType {
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(int64(d))
+		return nil
+	}
+
+	if isStructured(fv.Type()) {
+		return setStructured(fv, value)
+	}
+
+	switch fv.Kind() {
+	case reflect.String:
+		fv.SetString(value)
+	case reflect.Bool:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return err
+		}
+		fv.SetBool(b)
+	case reflect.Int, reflect.Int64:
+		n, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return err
+		}
+		fv.SetInt(n)
+	case reflect.Float32, reflect.Float64:
+		f, err := strconv.ParseFloat(value, fv.Type().Bits())
+		if err != nil {
+			return err
+		}
+		fv.SetFloat(f)
+	case reflect.Slice:
+		var items []string
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		slice := reflect.MakeSlice(fv.Type(), len(items), len(items))
+		for i, item := range items {
+			if err := setField(slice.Index(i), item); err != nil {
+				return err
+			}
+		}
+		fv.Set(slice)
+	default:
+		return fmt.Errorf("unsupported field type %s", fv.Type())
+	}
+	return nil
+}
+
+// isStructured сообщает, что поле нельзя задать списком через запятую:
+// словарь или список составных значений
+func isStructured(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Map:
+		return true
+	case reflect.Slice:
+		switch t.Elem().Kind() {
+		case reflect.Struct, reflect.Map, reflect.Slice:
+			return true
+		}
+	}
+	return false
+}
+
+// setStructured разбирает значение как JSON или YAML (JSON - подмножество YAML)
+// с ключами из yaml-тегов и заменяет им поле целиком
+func setStructured(fv reflect.Value, value string) error {
+	parsed := reflect.New(fv.Type())
+	if err := yaml.UnmarshalStrict([]byte(value), parsed.Interface()); err != nil {
+		return err
+	}
+	fv.Set(parsed.Elem())
+	return nil
+}
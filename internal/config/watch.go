@@ -0,0 +1,139 @@
+package config
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"reflect"
+	"sync"
+	"syscall"
+	"time"
+
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// Watcher перечитывает конфиг по SIGHUP или при изменении файла и передает
+// прошедший проверку конфиг подписчикам. Подписчики применяют только те настройки,
+// которые можно менять на лету; остальные вступают в силу после перезапуска.
+type Watcher struct {
+	path   string
+	logger logger.Logger
+
+	mu          sync.Mutex
+	current     *Config
+	modTime     time.Time
+	subscribers []func(*Config)
+}
+
+// NewWatcher создает наблюдатель за файлом path, cfg - уже загруженный конфиг
+func NewWatcher(path string, cfg *Config, log logger.Logger) *Watcher {
+	w := &Watcher{path: path, logger: log, current: cfg}
+	w.modTime = w.fileModTime()
+	return w
+}
+
+// Subscribe добавляет функцию, которая вызывается с новым конфигом после каждой успешной перезагрузки
+func (w *Watcher) Subscribe(fn func(*Config)) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.subscribers = append(w.subscribers, fn)
+}
+
+// Current возвращает последний примененный конфиг
+func (w *Watcher) Current() *Config {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.current
+}
+
+// Run ждет SIGHUP и раз в interval проверяет время изменения файла, пока не отменен ctx.
+// При interval <= 0 файл не проверяется.
+func (w *Watcher) Run(ctx context.Context, interval time.Duration) {
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	var tick <-chan time.Time
+	if interval > 0 && w.path != "" {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		tick = ticker.C
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-hup:
+			w.logger.Info("Received SIGHUP, reloading config")
+			w.Reload()
+		case <-tick:
+			if mt := w.fileModTime(); !mt.Equal(w.modTime) {
+				w.logger.Infow("Config file changed, reloading", "path", w.path)
+				w.Reload()
+			}
+		}
+	}
+}
+
+// Reload загружает и проверяет конфиг. Если он невалиден, продолжает действовать прежний.
+func (w *Watcher) Reload() error {
+	modTime := w.fileModTime()
+	cfg, err := Load(w.path)
+
+	w.mu.Lock()
+	// Невалидный файл не перечитывается до следующего изменения
+	w.modTime = modTime
+	if err != nil {
+		w.mu.Unlock()
+		w.logger.Errorw("Failed to reload config, keeping the current one", "error", err)
+		return err
+	}
+	old := w.current
+	w.current = cfg
+	subscribers := append([]func(*Config){}, w.subscribers...)
+	w.mu.Unlock()
+
+	if sections := restartRequired(old, cfg); len(sections) > 0 {
+		w.logger.Warnw("Config changes that require a restart were ignored", "sections", sections)
+	}
+
+	for _, fn := range subscribers {
+		fn(cfg)
+	}
+	w.logger.Info("Config reloaded")
+	return nil
+}
+
+func (w *Watcher) fileModTime() time.Time {
+	if w.path == "" {
+		return time.Time{}
+	}
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return time.Time{}
+	}
+	return info.ModTime()
+}
+
+// restartRequired возвращает измененные разделы, которые применяются только при запуске
+func restartRequired(old, cfg *Config) []string {
+	var sections []string
+	check := func(name string, a, b interface{}) {
+		if !reflect.DeepEqual(a, b) {
+			sections = append(sections, name)
+		}
+	}
+	check("telegram", old.Telegram, cfg.Telegram)
+	check("database", old.Database, cfg.Database)
+	check("admin", old.Admin, cfg.Admin)
+	check("openai_key", old.OpenAIKey, cfg.OpenAIKey)
+	check("admins", old.Admins, cfg.Admins)
+	check("timezone", old.TimeZone, cfg.TimeZone)
+	check("bells", old.Bells, cfg.Bells)
+	check("holidays", old.Holidays, cfg.Holidays)
+	check("campus", old.Campus, cfg.Campus)
+	check("shutdown_timeout", old.ShutdownTimeout, cfg.ShutdownTimeout)
+	check("reload_interval", old.ReloadInterval, cfg.ReloadInterval)
+	return sections
+}
@@ -0,0 +1,138 @@
+package config
+
+import (
+	"os"
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// recordingLogger запоминает предупреждения, остальное передает настоящему логгеру
+type recordingLogger struct {
+	logger.Logger
+
+	mu       sync.Mutex
+	warnings []string
+	fields   [][]interface{}
+}
+
+func (l *recordingLogger) Warnw(msg string, keysAndValues ...interface{}) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.warnings = append(l.warnings, msg)
+	l.fields = append(l.fields, keysAndValues)
+}
+
+func newWatcherTest(t *testing.T, content string) (*Watcher, *recordingLogger, string) {
+	t.Helper()
+	path := writeFile(t, "config.yaml", content)
+	cfg, err := Load(path)
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	log := &recordingLogger{Logger: logger.New("error")}
+	return NewWatcher(path, cfg, log), log, path
+}
+
+func TestWatcherReloadApplies(t *testing.T) {
+	w, log, path := newWatcherTest(t, minimalYAML+"log_level: info\n")
+
+	var got []*Config
+	w.Subscribe(func(cfg *Config) { got = append(got, cfg) })
+
+	if err := os.WriteFile(path, []byte(minimalYAML+"log_level: debug\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if w.Current().LogLevel != "debug" {
+		t.Errorf("current log_level = %q, want debug", w.Current().LogLevel)
+	}
+	if len(got) != 1 || got[0] != w.Current() {
+		t.Errorf("subscriber got %d configs, want the new one once", len(got))
+	}
+	if len(log.warnings) != 0 {
+		t.Errorf("unexpected warnings for a hot-reloadable change: %v", log.warnings)
+	}
+}
+
+func TestWatcherReloadKeepsConfigOnInvalidFile(t *testing.T) {
+	w, _, path := newWatcherTest(t, minimalYAML)
+	before := w.Current()
+
+	called := false
+	w.Subscribe(func(*Config) { called = true })
+
+	for name, content := range map[string]string{
+		"invalid value": minimalYAML + "log_level: verbose\n",
+		"broken yaml":   "telegram: [",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.Reload(); err == nil {
+			t.Errorf("%s: Reload returned nil", name)
+		}
+		if w.Current() != before {
+			t.Errorf("%s: current config was replaced", name)
+		}
+	}
+	if called {
+		t.Error("subscriber was called for an invalid config")
+	}
+}
+
+func TestWatcherReloadWarnsAboutRestart(t *testing.T) {
+	w, log, path := newWatcherTest(t, minimalYAML)
+
+	changed := `
+telegram:
+  token: other-token
+database:
+  dsn: file-dsn
+admins: [1]
+log_level: warn
+`
+	if err := os.WriteFile(path, []byte(changed), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Reload(); err != nil {
+		t.Fatalf("Reload: %v", err)
+	}
+
+	if w.Current().LogLevel != "warn" {
+		t.Errorf("hot-reloadable log_level was not applied")
+	}
+	if len(log.warnings) != 1 {
+		t.Fatalf("warnings = %v, want one restart warning", log.warnings)
+	}
+	fields := log.fields[0]
+	if len(fields) != 2 || fields[0] != "sections" || !reflect.DeepEqual(fields[1], []string{"telegram", "admins"}) {
+		t.Errorf("warning fields = %v, want sections [telegram admins]", fields)
+	}
+}
+
+func TestRestartRequired(t *testing.T) {
+	base := defaults()
+	base.Telegram.Token = "token"
+
+	same := *base
+	same.LogLevel = "debug"
+	same.AI.Model = "gpt-4o"
+	if sections := restartRequired(base, &same); len(sections) != 0 {
+		t.Errorf("hot-reloadable changes reported as restart-only: %v", sections)
+	}
+
+	changed := *base
+	changed.Telegram.Mode = "webhook"
+	changed.TimeZone = "Europe/Moscow"
+	changed.ShutdownTimeout = 0
+	want := []string{"telegram", "timezone", "shutdown_timeout"}
+	if sections := restartRequired(base, &changed); !reflect.DeepEqual(sections, want) {
+		t.Errorf("sections = %v, want %v", sections, want)
+	}
+}
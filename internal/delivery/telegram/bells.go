@@ -0,0 +1,114 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// bellsHandler обрабатывает команду /bells (просмотр и выбор расписания звонков)
+func bellsHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		schedules := svc.BellSchedules()
+		if len(schedules) == 0 {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "bells.not_configured"))
+			return
+		}
+
+		current, ok, err := svc.UserBellSchedule(ctx, userID)
+		if err != nil {
+			log.Errorw("Failed to get bell schedule", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "bells.error"))
+			return
+		}
+
+		text := i18n.T(lang, "bells.not_selected")
+		if ok {
+			text = formatBellSchedule(lang, current)
+		}
+
+		rows := make([][]models.InlineKeyboardButton, 0, len(schedules))
+		for _, bs := range schedules {
+			label := bs.Name
+			if ok && bs.Name == current.Name {
+				label = "✅ " + label
+			}
+			rows = append(rows, []models.InlineKeyboardButton{{
+				Text:         label,
+				CallbackData: "bells:" + bs.Name,
+			}})
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        text,
+			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: rows},
+		}); err != nil {
+			log.Errorw("Failed to send bell schedule", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// bellsCallbackHandler сохраняет выбранное пользователем расписание звонков
+func bellsCallbackHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		query := update.CallbackQuery
+		userID := query.From.ID
+		lang := userLang(ctx, svc, &query.From)
+		name := strings.TrimPrefix(query.Data, "bells:")
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+
+		if err := svc.SetUserBellSchedule(ctx, userID, name); err != nil {
+			log.Errorw("Failed to set bell schedule", "error", err, "userID", userID, "name", name)
+			return
+		}
+
+		msg := query.Message.Message
+		if msg == nil {
+			return
+		}
+
+		bs, _, _ := svc.UserBellSchedule(ctx, userID)
+		if _, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    msg.Chat.ID,
+			MessageID: msg.ID,
+			Text:      i18n.T(lang, "bells.selected") + formatBellSchedule(lang, bs),
+		}); err != nil {
+			log.Errorw("Failed to confirm bell schedule", "error", err, "chatID", msg.Chat.ID)
+		}
+	}
+}
+
+// formatBellSchedule форматирует расписание звонков
+func formatBellSchedule(lang i18n.Lang, bs domain.BellSchedule) string {
+	var sb strings.Builder
+	sb.WriteString(i18n.T(lang, "bells.title", bs.Name))
+	for _, slot := range bs.Slots {
+		sb.WriteString(i18n.T(lang, "bells.slot",
+			slot.Number, slot.StartTime.Format("15:04"), slot.EndTime.Format("15:04")))
+	}
+	return sb.String()
+}
+
+// bellScheduleNames перечисляет названия расписаний звонков через запятую
+func bellScheduleNames(svc *service.Service) string {
+	schedules := svc.BellSchedules()
+	names := make([]string, 0, len(schedules))
+	for _, bs := range schedules {
+		names = append(names, bs.Name)
+	}
+	return strings.Join(names, ", ")
+}
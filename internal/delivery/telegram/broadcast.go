@@ -0,0 +1,359 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// Ограничения рассылки. Telegram допускает около 30 сообщений в секунду на бота
+// и одно сообщение в секунду в один чат.
+const (
+	broadcastPerSecond = 25
+	broadcastWorkers   = 4
+	broadcastRetries   = 3
+	// broadcastChatInterval - минимальный интервал между сообщениями в один чат
+	broadcastChatInterval = time.Second
+)
+
+// broadcastDraft - рассылка, ожидающая подтверждения
+type broadcastDraft struct {
+	text  string
+	group string // Расписание звонков получателей, пусто - все пользователи
+}
+
+// pendingBroadcasts хранит рассылки, ожидающие подтверждения, по ID администратора
+var pendingBroadcasts = struct {
+	sync.Mutex
+	drafts map[int64]broadcastDraft
+}{drafts: make(map[int64]broadcastDraft)}
+
+// dropPendingBroadcast удаляет неподтвержденную рассылку администратора
+func dropPendingBroadcast(userID int64) {
+	pendingBroadcasts.Lock()
+	defer pendingBroadcasts.Unlock()
+	delete(pendingBroadcasts.drafts, userID)
+}
+
+// parseBroadcast разбирает аргументы /broadcast [#группа] <текст>.
+// Группа - название расписания звонков, как в /bells.
+func parseBroadcast(args string) broadcastDraft {
+	args = strings.TrimSpace(args)
+	if !strings.HasPrefix(args, "#") {
+		return broadcastDraft{text: args}
+	}
+
+	group, text, _ := strings.Cut(args[1:], " ")
+	return broadcastDraft{text: strings.TrimSpace(text), group: group}
+}
+
+// broadcastHandler обрабатывает команду администратора /broadcast [#группа] <текст>:
+// показывает предпросмотр и число получателей, рассылка начинается после подтверждения
+func broadcastHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		draft := parseBroadcast(strings.TrimPrefix(update.Message.Text, "/broadcast"))
+		if draft.text == "" {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "broadcast.usage"))
+			return
+		}
+		if textLength(draft.text) > maxMessageLength {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "broadcast.too_long", maxMessageLength))
+			return
+		}
+
+		recipients, err := svc.BroadcastRecipients(ctx, draft.group)
+		if errors.Is(err, service.ErrUnknownBellSchedule) {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "broadcast.unknown_group", draft.group, bellScheduleNames(svc)))
+			return
+		}
+		if err != nil {
+			log.Errorw("Failed to list broadcast recipients", "error", err)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "broadcast.error"))
+			return
+		}
+
+		pendingBroadcasts.Lock()
+		pendingBroadcasts.drafts[userID] = draft
+		pendingBroadcasts.Unlock()
+
+		preview := i18n.T(lang, "broadcast.preview", len(recipients))
+		if draft.group != "" {
+			preview = i18n.T(lang, "broadcast.preview_group", draft.group, len(recipients))
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   preview + "\n\n" + draft.text,
+			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{{
+				{Text: i18n.T(lang, "broadcast.send"), CallbackData: "broadcast:send"},
+				{Text: i18n.T(lang, "broadcast.cancel"), CallbackData: "broadcast:cancel"},
+			}}},
+		}); err != nil {
+			log.Errorw("Failed to send broadcast preview", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// broadcastCallbackHandler запускает или отменяет рассылку. Формат данных: broadcast:send|cancel.
+// Очередь живет в памяти процесса: при остановке бота рассылка прерывается,
+// неотправленные сообщения не сохраняются, а администратор получает отчет
+// с их числом, чтобы повторить рассылку после запуска.
+func broadcastCallbackHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		query := update.CallbackQuery
+		userID := query.From.ID
+		lang := userLang(ctx, svc, &query.From)
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+
+		msg := query.Message.Message
+		if msg == nil {
+			return
+		}
+		chatID := msg.Chat.ID
+
+		pendingBroadcasts.Lock()
+		draft, ok := pendingBroadcasts.drafts[userID]
+		delete(pendingBroadcasts.drafts, userID)
+		pendingBroadcasts.Unlock()
+
+		editStatus := func(status string) {
+			if _, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+				ChatID:    chatID,
+				MessageID: msg.ID,
+				Text:      status,
+			}); err != nil {
+				log.Errorw("Failed to update broadcast status", "error", err, "chatID", chatID)
+			}
+		}
+
+		switch {
+		case !ok:
+			editStatus(i18n.T(lang, "broadcast.expired"))
+			return
+		case query.Data != "broadcast:send":
+			editStatus(i18n.T(lang, "broadcast.cancelled"))
+			return
+		}
+
+		recipients, err := svc.BroadcastRecipients(ctx, draft.group)
+		if err != nil {
+			log.Errorw("Failed to list broadcast recipients", "error", err, "group", draft.group)
+			editStatus(i18n.T(lang, "broadcast.error"))
+			return
+		}
+
+		editStatus(i18n.T(lang, "broadcast.sending", len(recipients)))
+		log.Infow("Broadcast started", "recipients", len(recipients), "group", draft.group)
+
+		report := newBroadcastQueue(b).send(ctx, recipients, draft.text, func(recipient int64) {
+			if err := svc.MarkUserBlocked(ctx, recipient); err != nil {
+				log.Errorw("Failed to mark user as blocked", "error", err, "userID", recipient)
+			}
+		}, log)
+
+		log.Infow("Broadcast finished", "delivered", report.delivered,
+			"blocked", report.blocked, "failed", report.failed, "stopped", report.stopped)
+
+		text := i18n.T(lang, "broadcast.report", report.delivered, report.blocked, report.failed)
+		if report.stopped > 0 {
+			text += i18n.T(lang, "broadcast.report_stopped", report.stopped)
+		}
+
+		// Контекст мог быть отменен при остановке бота, отчет все равно нужен
+		if err := sendMessage(context.WithoutCancel(ctx), b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		}); err != nil {
+			log.Errorw("Failed to send broadcast report", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// broadcastReport - итоги рассылки
+type broadcastReport struct {
+	delivered int
+	blocked   int // пользователь заблокировал бота или удалил аккаунт
+	failed    int // ошибка отправки
+	stopped   int // не отправлено: рассылка прервана остановкой бота
+}
+
+// broadcastQueue отправляет сообщения несколькими воркерами с общим ограничением скорости
+// и не чаще broadcastChatInterval в один чат. Ответ 429 приостанавливает всю очередь на retry_after.
+type broadcastQueue struct {
+	b    *bot.Bot
+	tick *time.Ticker
+
+	mu         sync.Mutex
+	pauseUntil time.Time
+	// nextSend - время, раньше которого в чат нельзя отправлять
+	nextSend map[int64]time.Time
+}
+
+func newBroadcastQueue(b *bot.Bot) *broadcastQueue {
+	return &broadcastQueue{
+		b:        b,
+		tick:     time.NewTicker(time.Second / broadcastPerSecond),
+		nextSend: make(map[int64]time.Time),
+	}
+}
+
+// send рассылает text получателям, пока не отменен ctx. onBlocked вызывается для тех,
+// кто заблокировал бота.
+func (q *broadcastQueue) send(ctx context.Context, recipients []int64, text string, onBlocked func(int64), log logger.Logger) broadcastReport {
+	defer q.tick.Stop()
+
+	var (
+		mu     sync.Mutex
+		report broadcastReport
+		wg     sync.WaitGroup
+	)
+	jobs := make(chan int64)
+
+	for i := 0; i < broadcastWorkers; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for chatID := range jobs {
+				err := q.deliver(ctx, chatID, text)
+
+				mu.Lock()
+				switch {
+				case err == nil:
+					report.delivered++
+				case errors.Is(err, bot.ErrorForbidden):
+					report.blocked++
+				case ctx.Err() != nil && errors.Is(err, ctx.Err()):
+					report.stopped++
+				default:
+					report.failed++
+				}
+				mu.Unlock()
+
+				if errors.Is(err, bot.ErrorForbidden) {
+					onBlocked(chatID)
+				} else if err != nil && ctx.Err() == nil {
+					log.Warnw("Failed to deliver broadcast message", "error", err, "chatID", chatID)
+				}
+			}
+		}()
+	}
+
+	sent := 0
+queue:
+	for _, chatID := range recipients {
+		select {
+		case jobs <- chatID:
+			sent++
+		case <-ctx.Done():
+			break queue
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	report.stopped += len(recipients) - sent
+	return report
+}
+
+// deliver отправляет одно сообщение, повторяя попытку после 429
+func (q *broadcastQueue) deliver(ctx context.Context, chatID int64, text string) error {
+	for attempt := 0; ; attempt++ {
+		if err := q.waitChat(ctx, chatID); err != nil {
+			return err
+		}
+		if err := q.wait(ctx); err != nil {
+			return err
+		}
+
+		_, err := q.b.SendMessage(ctx, &bot.SendMessageParams{ChatID: chatID, Text: text})
+		var tooMany *bot.TooManyRequestsError
+		if !errors.As(err, &tooMany) || attempt >= broadcastRetries {
+			return err
+		}
+
+		q.pause(time.Duration(tooMany.RetryAfter) * time.Second)
+	}
+}
+
+// waitChat занимает для чата следующий слот не раньше broadcastChatInterval
+// после предыдущего и дожидается его
+func (q *broadcastQueue) waitChat(ctx context.Context, chatID int64) error {
+	q.mu.Lock()
+	now := time.Now()
+	at := q.nextSend[chatID]
+	if at.Before(now) {
+		at = now
+	}
+	q.nextSend[chatID] = at.Add(broadcastChatInterval)
+	q.mu.Unlock()
+
+	return sleepUntil(ctx, at)
+}
+
+// sleepUntil ждет момента t или отмены ctx
+func sleepUntil(ctx context.Context, t time.Time) error {
+	delay := time.Until(t)
+	if delay <= 0 {
+		return ctx.Err()
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+// wait дожидается паузы после 429 и очередного разрешения по скорости
+func (q *broadcastQueue) wait(ctx context.Context) error {
+	for {
+		q.mu.Lock()
+		delay := time.Until(q.pauseUntil)
+		q.mu.Unlock()
+		if delay <= 0 {
+			break
+		}
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-q.tick.C:
+		return nil
+	}
+}
+
+// pause приостанавливает отправку всеми воркерами на d
+func (q *broadcastQueue) pause(d time.Duration) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if until := time.Now().Add(d); until.After(q.pauseUntil) {
+		q.pauseUntil = until
+	}
+}
@@ -0,0 +1,177 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+const (
+	tooManyRequests = `{"ok":false,"error_code":429,"description":"Too Many Requests: retry after 1","parameters":{"retry_after":1}}`
+	blockedByUser   = `{"ok":false,"error_code":403,"description":"Forbidden: bot was blocked by the user"}`
+	chatNotFound    = `{"ok":false,"error_code":400,"description":"Bad Request: chat not found"}`
+	messageSent     = `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`
+)
+
+func TestBroadcastDeliverRetriesAfter429(t *testing.T) {
+	api := newFakeBotAPI(t)
+	var attempts atomic.Int32
+	api.respond("sendMessage", func(map[string]string) (int, string) {
+		if attempts.Add(1) == 1 {
+			return http.StatusTooManyRequests, tooManyRequests
+		}
+		return http.StatusOK, messageSent
+	})
+
+	q := newBroadcastQueue(api.bot(t))
+	defer q.tick.Stop()
+
+	start := time.Now()
+	if err := q.deliver(context.Background(), 1, "hello"); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if n := attempts.Load(); n != 2 {
+		t.Errorf("sendMessage called %d times, want 2", n)
+	}
+	if elapsed := time.Since(start); elapsed < time.Second {
+		t.Errorf("retried after %s, want at least retry_after", elapsed)
+	}
+}
+
+func TestBroadcastDeliverStopsOnCancelDuring429(t *testing.T) {
+	api := newFakeBotAPI(t)
+	api.respond("sendMessage", func(map[string]string) (int, string) {
+		return http.StatusTooManyRequests, tooManyRequests
+	})
+
+	q := newBroadcastQueue(api.bot(t))
+	defer q.tick.Stop()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 200*time.Millisecond)
+	defer cancel()
+
+	if err := q.deliver(ctx, 1, "hello"); !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("deliver err = %v, want the context error", err)
+	}
+	if n := len(api.sent("sendMessage")); n != 1 {
+		t.Errorf("sendMessage called %d times while paused, want 1", n)
+	}
+}
+
+func TestBroadcastDeliverForbidden(t *testing.T) {
+	api := newFakeBotAPI(t)
+	api.respond("sendMessage", func(map[string]string) (int, string) {
+		return http.StatusForbidden, blockedByUser
+	})
+
+	q := newBroadcastQueue(api.bot(t))
+	defer q.tick.Stop()
+
+	if err := q.deliver(context.Background(), 1, "hello"); !errors.Is(err, bot.ErrorForbidden) {
+		t.Fatalf("deliver err = %v, want ErrorForbidden", err)
+	}
+	if n := len(api.sent("sendMessage")); n != 1 {
+		t.Errorf("sendMessage called %d times, a blocked chat must not be retried", n)
+	}
+}
+
+func TestBroadcastSendReport(t *testing.T) {
+	api := newFakeBotAPI(t)
+	api.respond("sendMessage", func(params map[string]string) (int, string) {
+		switch params["chat_id"] {
+		case "2", "4":
+			return http.StatusForbidden, blockedByUser
+		case "3":
+			return http.StatusBadRequest, chatNotFound
+		}
+		return http.StatusOK, messageSent
+	})
+
+	var (
+		mu      sync.Mutex
+		blocked []int64
+	)
+	onBlocked := func(chatID int64) {
+		mu.Lock()
+		defer mu.Unlock()
+		blocked = append(blocked, chatID)
+	}
+
+	q := newBroadcastQueue(api.bot(t))
+	report := q.send(context.Background(), []int64{1, 2, 3, 4, 5}, "hello", onBlocked, logger.New("error"))
+
+	want := broadcastReport{delivered: 2, blocked: 2, failed: 1}
+	if report != want {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+	sort.Slice(blocked, func(i, j int) bool { return blocked[i] < blocked[j] })
+	if len(blocked) != 2 || blocked[0] != 2 || blocked[1] != 4 {
+		t.Errorf("onBlocked called for %v, want [2 4]", blocked)
+	}
+}
+
+func TestBroadcastSendCancelled(t *testing.T) {
+	api := newFakeBotAPI(t)
+	q := newBroadcastQueue(api.bot(t))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	report := q.send(ctx, []int64{1, 2, 3}, "hello", func(int64) {}, logger.New("error"))
+	if want := (broadcastReport{stopped: 3}); report != want {
+		t.Errorf("report = %+v, want %+v", report, want)
+	}
+}
+
+func TestBroadcastChatInterval(t *testing.T) {
+	api := newFakeBotAPI(t)
+	api.respond("sendMessage", func(map[string]string) (int, string) {
+		return http.StatusOK, messageSent
+	})
+
+	q := newBroadcastQueue(api.bot(t))
+	defer q.tick.Stop()
+	ctx := context.Background()
+
+	start := time.Now()
+	for _, chatID := range []int64{1, 2} {
+		if err := q.deliver(ctx, chatID, "hello"); err != nil {
+			t.Fatalf("deliver: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed >= broadcastChatInterval {
+		t.Errorf("different chats waited %s, want no per-chat pause", elapsed)
+	}
+
+	if err := q.deliver(ctx, 1, "again"); err != nil {
+		t.Fatalf("deliver: %v", err)
+	}
+	if elapsed := time.Since(start); elapsed < broadcastChatInterval {
+		t.Errorf("second message to the same chat after %s, want at least %s", elapsed, broadcastChatInterval)
+	}
+}
+
+func TestParseBroadcast(t *testing.T) {
+	tests := []struct {
+		args string
+		want broadcastDraft
+	}{
+		{args: " Пары отменены ", want: broadcastDraft{text: "Пары отменены"}},
+		{args: " #college Пары отменены", want: broadcastDraft{text: "Пары отменены", group: "college"}},
+		{args: "#college", want: broadcastDraft{group: "college"}},
+		{args: "", want: broadcastDraft{}},
+	}
+	for _, tt := range tests {
+		if got := parseBroadcast(tt.args); got != tt.want {
+			t.Errorf("parseBroadcast(%q) = %+v, want %+v", tt.args, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,214 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// dayHandler обрабатывает команду /day [дата или день недели].
+// Без аргумента показывает календарь для выбора даты.
+func dayHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		arg := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/day"))
+		now := svc.Now(ctx, userID)
+
+		if arg == "" {
+			if err := sendMessage(ctx, b, &bot.SendMessageParams{
+				ChatID:      chatID,
+				Text:        i18n.T(lang, "day.calendar"),
+				ReplyMarkup: calendarKeyboard(lang, now, now),
+			}); err != nil {
+				log.Errorw("Failed to send calendar", "error", err, "chatID", chatID)
+			}
+			return
+		}
+
+		date, err := parseDate(arg, now)
+		if err != nil {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "day.bad_date"))
+			return
+		}
+
+		handleDaySchedule(ctx, b, svc, log, lang, userID, chatID, date)
+	}
+}
+
+// scheduleCallbackHandler обрабатывает кнопки меню /schedule
+func scheduleCallbackHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		query := update.CallbackQuery
+		userID := query.From.ID
+		lang := userLang(ctx, svc, &query.From)
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+
+		msg := query.Message.Message
+		if msg == nil {
+			return
+		}
+		chatID := msg.Chat.ID
+		now := svc.Now(ctx, userID)
+
+		switch query.Data {
+		case "schedule_today":
+			handleDaySchedule(ctx, b, svc, log, lang, userID, chatID, now)
+		case "schedule_tomorrow":
+			handleDaySchedule(ctx, b, svc, log, lang, userID, chatID, now.AddDate(0, 0, 1))
+		case "schedule_week":
+			sendWeekSchedule(ctx, b, svc, log, lang, userID, chatID, 0)
+		case "schedule_nextweek":
+			sendWeekSchedule(ctx, b, svc, log, lang, userID, chatID, 1)
+		}
+	}
+}
+
+// calendarCallbackHandler обрабатывает кнопки календаря.
+// Формат данных: cal:m:2026-10 (перелистывание), cal:d:2026-10-21 (выбор дня), cal:- (пустая кнопка)
+func calendarCallbackHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		query := update.CallbackQuery
+		userID := query.From.ID
+		lang := userLang(ctx, svc, &query.From)
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+
+		msg := query.Message.Message
+		if msg == nil {
+			return
+		}
+		chatID := msg.Chat.ID
+		now := svc.Now(ctx, userID)
+
+		parts := strings.SplitN(query.Data, ":", 3)
+		if len(parts) != 3 {
+			return
+		}
+
+		switch parts[1] {
+		case "m":
+			month, err := time.ParseInLocation("2006-01", parts[2], now.Location())
+			if err != nil {
+				return
+			}
+			if _, err := b.EditMessageReplyMarkup(ctx, &bot.EditMessageReplyMarkupParams{
+				ChatID:      chatID,
+				MessageID:   msg.ID,
+				ReplyMarkup: calendarKeyboard(lang, month, now),
+			}); err != nil {
+				log.Errorw("Failed to switch calendar month", "error", err, "chatID", chatID)
+			}
+		case "d":
+			date, err := time.ParseInLocation("2006-01-02", parts[2], now.Location())
+			if err != nil {
+				return
+			}
+			handleDaySchedule(ctx, b, svc, log, lang, userID, chatID, date)
+		}
+	}
+}
+
+// calendarData возвращает callback data для открытия календаря на месяце даты
+func calendarData(date time.Time) string {
+	return "cal:m:" + date.Format("2006-01")
+}
+
+// calendarKeyboard строит inline-календарь на месяц, в котором находится month.
+// Сегодняшний день отмечен точкой.
+func calendarKeyboard(lang i18n.Lang, month, today time.Time) *models.InlineKeyboardMarkup {
+	first := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	empty := models.InlineKeyboardButton{Text: " ", CallbackData: "cal:-:"}
+
+	rows := [][]models.InlineKeyboardButton{
+		{
+			{Text: "«", CallbackData: calendarData(first.AddDate(0, -1, 0))},
+			{Text: fmt.Sprintf("%s %d", i18n.Month(lang, first.Month()), first.Year()), CallbackData: "cal:-:"},
+			{Text: "»", CallbackData: calendarData(first.AddDate(0, 1, 0))},
+		},
+	}
+
+	header := make([]models.InlineKeyboardButton, 0, 7)
+	for _, day := range []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday, time.Sunday} {
+		header = append(header, models.InlineKeyboardButton{Text: i18n.ShortWeekday(lang, day), CallbackData: "cal:-:"})
+	}
+	rows = append(rows, header)
+
+	week := make([]models.InlineKeyboardButton, 0, 7)
+	for i := 0; i < (int(first.Weekday())+6)%7; i++ {
+		week = append(week, empty)
+	}
+
+	for date := first; date.Month() == first.Month(); date = date.AddDate(0, 0, 1) {
+		label := strconv.Itoa(date.Day())
+		if date.Year() == today.Year() && date.YearDay() == today.YearDay() {
+			label = "•" + label
+		}
+		week = append(week, models.InlineKeyboardButton{
+			Text:         label,
+			CallbackData: "cal:d:" + date.Format("2006-01-02"),
+		})
+
+		if len(week) == 7 {
+			rows = append(rows, week)
+			week = make([]models.InlineKeyboardButton, 0, 7)
+		}
+	}
+
+	if len(week) > 0 {
+		for len(week) < 7 {
+			week = append(week, empty)
+		}
+		rows = append(rows, week)
+	}
+
+	return &models.InlineKeyboardMarkup{InlineKeyboard: rows}
+}
+
+// parseDate разбирает дату в форматах 2006-01-02, 02.01.2006, 02.01,
+// а также "сегодня", "завтра", "послезавтра" и дни недели (ближайший, включая сегодня)
+// на русском или английском
+func parseDate(text string, now time.Time) (time.Time, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch text {
+	case "сегодня", "today":
+		return today, nil
+	case "завтра", "tomorrow":
+		return today.AddDate(0, 0, 1), nil
+	case "послезавтра", "day after tomorrow":
+		return today.AddDate(0, 0, 2), nil
+	}
+
+	if day, ok := i18n.ParseWeekday(text); ok {
+		return today.AddDate(0, 0, (int(day)-int(today.Weekday())+7)%7), nil
+	}
+
+	for _, layout := range []string{"2006-01-02", "02.01.2006", "2.1.2006"} {
+		if date, err := time.ParseInLocation(layout, text, now.Location()); err == nil {
+			return date, nil
+		}
+	}
+
+	for _, layout := range []string{"02.01", "2.1"} {
+		if date, err := time.ParseInLocation(layout, text, now.Location()); err == nil {
+			return time.Date(today.Year(), date.Month(), date.Day(), 0, 0, 0, 0, now.Location()), nil
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("invalid date %q", text)
+}
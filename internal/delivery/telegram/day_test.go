@@ -0,0 +1,123 @@
+package telegram
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/i18n"
+)
+
+func TestParseDate(t *testing.T) {
+	loc := time.FixedZone("MSK", 3*60*60)
+	// Понедельник, вечер
+	now := time.Date(2026, 10, 19, 21, 30, 0, 0, loc)
+
+	tests := []struct {
+		text    string
+		want    string
+		wantErr bool
+	}{
+		{text: "сегодня", want: "2026-10-19"},
+		{text: " Today ", want: "2026-10-19"},
+		{text: "завтра", want: "2026-10-20"},
+		{text: "послезавтра", want: "2026-10-21"},
+		{text: "day after tomorrow", want: "2026-10-21"},
+		{text: "понедельник", want: "2026-10-19"},
+		{text: "пт", want: "2026-10-23"},
+		{text: "Sunday", want: "2026-10-25"},
+		{text: "2026-11-04", want: "2026-11-04"},
+		{text: "04.11.2026", want: "2026-11-04"},
+		{text: "4.11.2026", want: "2026-11-04"},
+		{text: "04.11", want: "2026-11-04"},
+		{text: "1.9", want: "2026-09-01"},
+		{text: "31.02", wantErr: true},
+		{text: "вчера", wantErr: true},
+		{text: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		got, err := parseDate(tt.text, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("parseDate(%q) = %s, want an error", tt.text, got.Format("2006-01-02"))
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("parseDate(%q): %v", tt.text, err)
+			continue
+		}
+		if got.Format("2006-01-02") != tt.want || got.Location() != loc || got.Hour() != 0 {
+			t.Errorf("parseDate(%q) = %s, want %s 00:00 in the user's zone", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestCalendarKeyboard(t *testing.T) {
+	today := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		month     time.Time
+		weeks     int
+		leading   int // пустых кнопок перед первым числом
+		todayMark string
+		prev      string
+		next      string
+	}{
+		// 1 октября 2026 - четверг
+		{name: "october", month: today, weeks: 5, leading: 3, todayMark: "•19", prev: "cal:m:2026-09", next: "cal:m:2026-11"},
+		// 1 февраля 2027 - понедельник, 28 дней ровно в четыре недели
+		{name: "february", month: time.Date(2027, 2, 10, 0, 0, 0, 0, time.UTC), weeks: 4, leading: 0, prev: "cal:m:2027-01", next: "cal:m:2027-03"},
+		// Перелистывание через год
+		{name: "december", month: time.Date(2026, 12, 31, 0, 0, 0, 0, time.UTC), weeks: 5, leading: 1, prev: "cal:m:2026-11", next: "cal:m:2027-01"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows := calendarKeyboard(i18n.Russian, tt.month, today).InlineKeyboard
+
+			// Заголовок, дни недели, недели месяца
+			if len(rows) != 2+tt.weeks {
+				t.Fatalf("got %d rows, want %d", len(rows), 2+tt.weeks)
+			}
+			if rows[0][0].CallbackData != tt.prev || rows[0][2].CallbackData != tt.next {
+				t.Errorf("navigation = %q / %q, want %q / %q", rows[0][0].CallbackData, rows[0][2].CallbackData, tt.prev, tt.next)
+			}
+			if rows[1][0].Text != i18n.ShortWeekday(i18n.Russian, time.Monday) {
+				t.Errorf("week starts with %q, want Monday", rows[1][0].Text)
+			}
+
+			days, marked := 0, ""
+			for i, row := range rows[2:] {
+				if len(row) != 7 {
+					t.Errorf("week %d has %d buttons, want 7", i, len(row))
+				}
+				for j, button := range row {
+					if button.Text == " " {
+						if i == 0 && j >= tt.leading {
+							t.Errorf("unexpected blank at %d in the first week", j)
+						}
+						continue
+					}
+					days++
+					if strings.HasPrefix(button.Text, "•") {
+						marked = button.Text
+					}
+				}
+			}
+
+			first := time.Date(tt.month.Year(), tt.month.Month(), 1, 0, 0, 0, 0, time.UTC)
+			if want := first.AddDate(0, 1, -1).Day(); days != want {
+				t.Errorf("got %d day buttons, want %d", days, want)
+			}
+			if marked != tt.todayMark {
+				t.Errorf("today marked as %q, want %q", marked, tt.todayMark)
+			}
+			if got := rows[2][tt.leading].CallbackData; got != "cal:d:"+first.Format("2006-01-02") {
+				t.Errorf("first day button = %q", got)
+			}
+		})
+	}
+}
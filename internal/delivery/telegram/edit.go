@@ -0,0 +1,268 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// EditState хранит поле пары, новое значение которого ожидается от пользователя
+type EditState struct {
+	Day      time.Weekday
+	LessonID string
+	Field    service.LessonField
+}
+
+var editStates = newSessionStore[EditState]()
+
+// editHandler обрабатывает команду /edit (выбор дня для редактирования)
+func editHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        i18n.T(lang, "edit.pick_day"),
+			ReplyMarkup: editDayKeyboard(lang),
+		}); err != nil {
+			log.Errorw("Failed to send edit menu", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// editCallbackHandler обрабатывает нажатия кнопок меню редактирования.
+// Формат данных: edit_<действие>:<день>[:<ID пары>[:<поле>]]
+func editCallbackHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		query := update.CallbackQuery
+		userID := query.From.ID
+		lang := userLang(ctx, svc, &query.From)
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+
+		msg := query.Message.Message
+		if msg == nil {
+			return
+		}
+		chatID := msg.Chat.ID
+
+		parts := strings.Split(query.Data, ":")
+		if len(parts) < 2 {
+			return
+		}
+		dayNum, err := strconv.Atoi(parts[1])
+		if err != nil || dayNum < 0 || dayNum > 6 {
+			return
+		}
+		day := time.Weekday(dayNum)
+
+		var lessonID string
+		if len(parts) > 2 {
+			lessonID = parts[2]
+		}
+
+		switch parts[0] {
+		case "edit_day", "edit_back":
+		case "edit_lesson":
+			showLessonMenu(ctx, b, svc, log, lang, userID, chatID, msg.ID, day, lessonID)
+			return
+		case "edit_field":
+			if len(parts) < 4 {
+				return
+			}
+			field := service.LessonField(parts[3])
+			unlock := dialogLocks.lock(userID)
+			editStates.set(userID, &EditState{Day: day, LessonID: lessonID, Field: field})
+			unlock()
+			askMissingField(ctx, b, lang, chatID, field)
+			return
+		case "edit_up", "edit_down":
+			delta := -1
+			if parts[0] == "edit_down" {
+				delta = 1
+			}
+			if err := svc.MoveLesson(ctx, userID, day, lessonID, delta); err != nil {
+				log.Errorw("Failed to move lesson", "error", err, "userID", userID, "lessonID", lessonID)
+			}
+		case "edit_del":
+			if err := svc.DeleteLesson(ctx, userID, day, lessonID); err != nil {
+				log.Errorw("Failed to delete lesson", "error", err, "userID", userID, "lessonID", lessonID)
+			}
+		default:
+			return
+		}
+
+		showLessonList(ctx, b, svc, log, lang, userID, chatID, msg.ID, day)
+	}
+}
+
+// handleEditInput принимает новое значение поля пары.
+// Возвращает false, если пользователь ничего не редактирует.
+// Вызывающий держит dialogLocks.lock(userID).
+func handleEditInput(ctx context.Context, b *bot.Bot, svc *service.Service, log logger.Logger, lang i18n.Lang, userID, chatID int64, text string) bool {
+	state, exists := editStates.get(userID)
+	if !exists {
+		return false
+	}
+
+	lesson, issues, err := svc.UpdateLessonField(ctx, userID, state.Day, state.LessonID, state.Field, text)
+	if errors.Is(err, service.ErrLessonNotFound) {
+		editStates.delete(userID)
+		sendErrorMessage(b, ctx, chatID, i18n.T(lang, "edit.not_found"))
+		return true
+	}
+	var validationErr *service.ValidationError
+	if errors.As(err, &validationErr) {
+		_ = sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "edit.rejected") + formatIssues(lang, validationErr.Issues),
+		})
+		askMissingField(ctx, b, lang, chatID, state.Field)
+		return true
+	}
+	if err != nil {
+		_ = sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "answer.retry"),
+		})
+		askMissingField(ctx, b, lang, chatID, state.Field)
+		return true
+	}
+
+	editStates.delete(userID)
+
+	reply := i18n.T(lang, "edit.updated") + lessonDetails(lesson)
+	if len(issues) > 0 {
+		reply += i18n.T(lang, "edit.warnings") + formatIssues(lang, issues)
+	}
+
+	if err := sendMessage(ctx, b, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        reply,
+		ReplyMarkup: lessonMenuKeyboard(lang, state.Day, lesson.ID),
+	}); err != nil {
+		log.Errorw("Failed to send updated lesson", "error", err, "chatID", chatID)
+	}
+	return true
+}
+
+// showLessonList показывает пары дня с кнопками выбора
+func showLessonList(ctx context.Context, b *bot.Bot, svc *service.Service, log logger.Logger, lang i18n.Lang, userID, chatID int64, messageID int, day time.Weekday) {
+	lessons, _ := svc.GetSchedule(ctx, userID, day)
+
+	text := i18n.T(lang, "edit.pick_lesson", i18n.Weekday(lang, day))
+	if len(lessons) == 0 {
+		text = i18n.T(lang, "edit.no_lessons", i18n.Weekday(lang, day))
+	}
+
+	rows := make([][]models.InlineKeyboardButton, 0, len(lessons)+1)
+	for i, lesson := range lessons {
+		rows = append(rows, []models.InlineKeyboardButton{{
+			Text:         fmt.Sprintf("%d. %s %s", i+1, lesson.StartTime.Format("15:04"), lesson.Name),
+			CallbackData: fmt.Sprintf("edit_lesson:%d:%s", day, lesson.ID),
+		}})
+	}
+	rows = append(rows, editDayKeyboard(lang).InlineKeyboard...)
+
+	if _, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        text,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: rows},
+	}); err != nil {
+		log.Errorw("Failed to show lesson list", "error", err, "chatID", chatID)
+	}
+}
+
+// showLessonMenu показывает пару и кнопки действий с ней
+func showLessonMenu(ctx context.Context, b *bot.Bot, svc *service.Service, log logger.Logger, lang i18n.Lang, userID, chatID int64, messageID int, day time.Weekday, lessonID string) {
+	lesson, err := svc.GetLesson(ctx, userID, day, lessonID)
+	if err != nil {
+		showLessonList(ctx, b, svc, log, lang, userID, chatID, messageID, day)
+		return
+	}
+
+	if _, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+		ChatID:      chatID,
+		MessageID:   messageID,
+		Text:        lessonDetails(lesson),
+		ReplyMarkup: lessonMenuKeyboard(lang, day, lesson.ID),
+	}); err != nil {
+		log.Errorw("Failed to show lesson menu", "error", err, "chatID", chatID)
+	}
+}
+
+// lessonDetails форматирует все поля пары
+func lessonDetails(lesson domain.Lesson) string {
+	var sb strings.Builder
+	sb.WriteString(lesson.Name + "\n")
+	sb.WriteString(fmt.Sprintf("🕒 %s - %s\n", lesson.StartTime.Format("15:04"), lesson.EndTime.Format("15:04")))
+	if lesson.Location != "" {
+		sb.WriteString(fmt.Sprintf("🏫 %s\n", lesson.Location))
+	}
+	if lesson.Teacher != "" {
+		sb.WriteString(fmt.Sprintf("👨‍🏫 %s\n", lesson.Teacher))
+	}
+	return sb.String()
+}
+
+// editDayKeyboard создает inline-клавиатуру выбора дня для редактирования
+func editDayKeyboard(lang i18n.Lang) *models.InlineKeyboardMarkup {
+	button := func(day time.Weekday) models.InlineKeyboardButton {
+		return models.InlineKeyboardButton{
+			Text:         i18n.ShortWeekday(lang, day),
+			CallbackData: fmt.Sprintf("edit_day:%d", day),
+		}
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{button(time.Monday), button(time.Tuesday), button(time.Wednesday), button(time.Thursday)},
+			{button(time.Friday), button(time.Saturday), button(time.Sunday)},
+		},
+	}
+}
+
+// lessonMenuKeyboard создает клавиатуру действий с парой
+func lessonMenuKeyboard(lang i18n.Lang, day time.Weekday, lessonID string) *models.InlineKeyboardMarkup {
+	data := func(action string) string {
+		return fmt.Sprintf("%s:%d:%s", action, day, lessonID)
+	}
+	field := func(f service.LessonField) string {
+		return fmt.Sprintf("edit_field:%d:%s:%s", day, lessonID, f)
+	}
+
+	return &models.InlineKeyboardMarkup{
+		InlineKeyboard: [][]models.InlineKeyboardButton{
+			{
+				{Text: i18n.T(lang, "edit.field.name"), CallbackData: field(service.FieldName)},
+				{Text: i18n.T(lang, "edit.field.time"), CallbackData: field(service.FieldTime)},
+			},
+			{
+				{Text: i18n.T(lang, "edit.field.location"), CallbackData: field(service.FieldLocation)},
+				{Text: i18n.T(lang, "edit.field.teacher"), CallbackData: field(service.FieldTeacher)},
+			},
+			{
+				{Text: i18n.T(lang, "edit.up"), CallbackData: data("edit_up")},
+				{Text: i18n.T(lang, "edit.down"), CallbackData: data("edit_down")},
+			},
+			{
+				{Text: i18n.T(lang, "edit.delete"), CallbackData: data("edit_del")},
+				{Text: i18n.T(lang, "edit.back"), CallbackData: fmt.Sprintf("edit_back:%d", day)},
+			},
+		},
+	}
+}
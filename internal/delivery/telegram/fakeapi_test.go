@@ -0,0 +1,98 @@
+package telegram
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/polyk005/tg_bot/internal/repository/inmemory"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// apiCall - вызов метода Bot API с параметрами формы
+type apiCall struct {
+	method string
+	params map[string]string
+}
+
+// fakeBotAPI - поддельный Telegram Bot API: запоминает вызовы и отвечает
+// заданным JSON (по умолчанию - успешным ответом)
+type fakeBotAPI struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	calls     []apiCall
+	responses map[string]func(params map[string]string) (int, string)
+}
+
+func newFakeBotAPI(t *testing.T) *fakeBotAPI {
+	t.Helper()
+	f := &fakeBotAPI{responses: map[string]func(params map[string]string) (int, string){}}
+	f.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		method := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+
+		params := map[string]string{}
+		if err := r.ParseMultipartForm(1 << 20); err == nil {
+			for k, v := range r.MultipartForm.Value {
+				params[k] = v[0]
+			}
+		}
+
+		f.mu.Lock()
+		f.calls = append(f.calls, apiCall{method: method, params: params})
+		respond := f.responses[method]
+		f.mu.Unlock()
+
+		status, body := http.StatusOK, `{"ok":true,"result":{"message_id":1,"date":0,"chat":{"id":1,"type":"private"}}}`
+		if respond != nil {
+			status, body = respond(params)
+		}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(f.Close)
+	return f
+}
+
+// respond задает ответ на метод; fn вызывается на каждый запрос с его параметрами
+func (f *fakeBotAPI) respond(method string, fn func(params map[string]string) (int, string)) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.responses[method] = fn
+}
+
+// sent возвращает вызовы метода method
+func (f *fakeBotAPI) sent(method string) []apiCall {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	var calls []apiCall
+	for _, c := range f.calls {
+		if c.method == method {
+			calls = append(calls, c)
+		}
+	}
+	return calls
+}
+
+func (f *fakeBotAPI) bot(t *testing.T) *bot.Bot {
+	t.Helper()
+	b, err := bot.New("1:test", bot.WithServerURL(f.URL), bot.WithSkipGetMe())
+	if err != nil {
+		t.Fatalf("bot.New: %v", err)
+	}
+	return b
+}
+
+// newTestService создает сервис поверх репозитория в памяти без ключа OpenAI
+func newTestService(t *testing.T, opts service.Options) *service.Service {
+	t.Helper()
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return service.New(inmemory.New(), service.NewAIService("", nil), logger.New("error"), opts)
+}
@@ -0,0 +1,49 @@
+package telegram
+
+import (
+	"html"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Разметка сообщений в режиме Telegram HTML (ParseMode: HTML).
+// Любой текст, пришедший от пользователя или из конфигурации (названия пар,
+// аудитории, преподаватели, праздники), должен проходить через escapeHTML
+// или функции ниже, иначе символы < > & сломают разбор сообщения.
+
+// htmlEscaper экранирует символы, которые Telegram считает разметкой
+var htmlEscaper = strings.NewReplacer("&", "&amp;", "<", "&lt;", ">", "&gt;", `"`, "&quot;")
+
+// htmlTag находит теги разметки при преобразовании в обычный текст
+var htmlTag = regexp.MustCompile(`<[^>]*>`)
+
+// escapeHTML экранирует текст для вставки в HTML-сообщение
+func escapeHTML(text string) string {
+	return htmlEscaper.Replace(text)
+}
+
+// bold выделяет текст жирным
+func bold(text string) string {
+	return "<b>" + escapeHTML(text) + "</b>"
+}
+
+// italic выделяет текст курсивом
+func italic(text string) string {
+	return "<i>" + escapeHTML(text) + "</i>"
+}
+
+// mono выводит текст моноширинным шрифтом
+func mono(text string) string {
+	return "<code>" + escapeHTML(text) + "</code>"
+}
+
+// timeRange форматирует интервал времени пары моноширинным шрифтом
+func timeRange(start, end time.Time) string {
+	return mono(start.Format("15:04") + "-" + end.Format("15:04"))
+}
+
+// plainText убирает разметку из HTML-сообщения, например для описания inline-результата
+func plainText(text string) string {
+	return html.UnescapeString(htmlTag.ReplaceAllString(text, ""))
+}
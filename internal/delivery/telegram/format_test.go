@@ -0,0 +1,164 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// unsafeNames - названия пар с символами HTML-разметки и их экранированный вид
+var unsafeNames = []struct {
+	name, escaped string
+}{
+	{"<b>x</b>", "&lt;b&gt;x&lt;/b&gt;"},
+	{"a & b", "a &amp; b"},
+	{`"q"`, "&quot;q&quot;"},
+}
+
+func TestFormatHelpers(t *testing.T) {
+	tests := []struct {
+		name string
+		got  string
+		want string
+	}{
+		{"escape plain", escapeHTML("Матанализ"), "Матанализ"},
+		{"escape tag", escapeHTML("<b>x</b>"), "&lt;b&gt;x&lt;/b&gt;"},
+		{"escape amp", escapeHTML("a & b"), "a &amp; b"},
+		{"escape quote", escapeHTML(`"q"`), "&quot;q&quot;"},
+		{"escape entity", escapeHTML("&amp;"), "&amp;amp;"},
+		{"bold", bold("a & b"), "<b>a &amp; b</b>"},
+		{"italic", italic("<i>"), "<i>&lt;i&gt;</i>"},
+		{"mono", mono(`"q"`), "<code>&quot;q&quot;</code>"},
+		{"plain text", plainText("<b>a &amp; b</b> <i>&lt;b&gt;x&lt;/b&gt;</i>"), "a & b <b>x</b>"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("got %q, want %q", tt.got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPlainTextRoundTrip(t *testing.T) {
+	for _, n := range unsafeNames {
+		if got := plainText(bold(n.name)); got != n.name {
+			t.Errorf("plainText(bold(%q)) = %q", n.name, got)
+		}
+	}
+}
+
+// newScheduleService создает сервис с парами unsafeNames в каждый будний день
+func newScheduleService(t *testing.T) *service.Service {
+	t.Helper()
+	svc := newTestService(t, service.Options{})
+
+	var lessons []domain.Lesson
+	for i, n := range unsafeNames {
+		start := time.Date(0, 1, 1, 9+2*i, 0, 0, 0, time.UTC)
+		lessons = append(lessons, domain.Lesson{Name: n.name, StartTime: start, EndTime: start.Add(90 * time.Minute)})
+	}
+	for day := time.Monday; day <= time.Saturday; day++ {
+		if _, err := svc.SaveSchedule(context.Background(), 1, day, lessons); err != nil {
+			t.Fatalf("SaveSchedule(%v): %v", day, err)
+		}
+	}
+	return svc
+}
+
+func TestScheduleEscapesLessonNames(t *testing.T) {
+	ctx := context.Background()
+	svc := newScheduleService(t)
+	log := logger.New("error")
+
+	date := svc.Now(ctx, 1)
+	if date.Weekday() == time.Sunday {
+		date = date.AddDate(0, 0, 1)
+	}
+	day, err := formatDaySchedule(ctx, svc, log, i18n.Russian, 1, date)
+	if err != nil {
+		t.Fatalf("formatDaySchedule: %v", err)
+	}
+	week := formatWeekSchedule(ctx, svc, log, i18n.Russian, 1, 0)
+
+	for name, text := range map[string]string{"day": day, "week": week} {
+		for _, n := range unsafeNames {
+			if !strings.Contains(text, "<b>"+n.escaped+"</b>") {
+				t.Errorf("%s output has no escaped %q:\n%s", name, n.name, text)
+			}
+			if !strings.Contains(plainText(text), n.name) {
+				t.Errorf("plainText of %s output lost %q", name, n.name)
+			}
+		}
+		if strings.Contains(text, "<b>x</b>") {
+			t.Errorf("%s output contains raw markup from a lesson name", name)
+		}
+	}
+}
+
+func TestSplitMessageShortText(t *testing.T) {
+	parts := splitMessage("<b>a &amp; b</b>", 100, true)
+	if len(parts) != 1 || parts[0] != "<b>a &amp; b</b>" {
+		t.Fatalf("parts = %q", parts)
+	}
+}
+
+func TestSplitMessageReopensTags(t *testing.T) {
+	text := "<b>" + strings.Repeat("жирный текст ", 40) + "</b>\n<i>" + strings.Repeat("курсив ", 40) + "</i>"
+	limit := 150
+
+	parts := splitMessage(text, limit, true)
+	if len(parts) < 2 {
+		t.Fatalf("text was not split: %d parts", len(parts))
+	}
+
+	var joined strings.Builder
+	for i, part := range parts {
+		if n := textLength(part); n > limit {
+			t.Errorf("part %d has length %d > %d", i, n, limit)
+		}
+		if open := openTags(part); len(open) != 0 {
+			t.Errorf("part %d leaves tags open: %q", i, open)
+		}
+		if strings.Count(part, "<b>")+strings.Count(part, "<i>") == 0 {
+			t.Errorf("part %d lost formatting: %q", i, part)
+		}
+		joined.WriteString(plainText(part))
+	}
+
+	if strings.Count(joined.String(), "жирный") != 40 || strings.Count(joined.String(), "курсив") != 40 {
+		t.Errorf("words were lost while splitting")
+	}
+}
+
+func TestSplitMessageKeepsEntities(t *testing.T) {
+	// Без пробелов и переводов строки разрез определяется только длиной
+	text := strings.Repeat("a&amp;", 100)
+
+	for limit := 70; limit < 90; limit++ {
+		parts := splitMessage(text, limit, true)
+		var joined strings.Builder
+		for i, part := range parts {
+			if textLength(part) > limit {
+				t.Errorf("limit %d: part %d is too long", limit, i)
+			}
+			if amp := strings.LastIndex(part, "&"); amp >= 0 && !strings.HasPrefix(part[amp:], "&amp;") {
+				t.Errorf("limit %d: part %d ends inside an entity: %q", limit, i, part)
+			}
+			if strings.HasPrefix(part, "amp;") || strings.HasPrefix(part, "mp;") || strings.HasPrefix(part, "p;") || strings.HasPrefix(part, ";") {
+				t.Errorf("limit %d: part %d starts inside an entity: %q", limit, i, part)
+			}
+			joined.WriteString(part)
+		}
+		if joined.String() != text {
+			t.Errorf("limit %d: parts do not add up to the original text", limit)
+		}
+	}
+}
@@ -2,6 +2,7 @@ package telegram
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 	"time"
@@ -9,6 +10,7 @@ import (
 	"github.com/go-telegram/bot"
 	"github.com/go-telegram/bot/models"
 	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
 	"github.com/polyk005/tg_bot/internal/service"
 	"github.com/polyk005/tg_bot/pkg/logger"
 )
@@ -16,68 +18,97 @@ import (
 // UserState хранит состояние пользователя при вводе расписания
 type UserState struct {
 	CurrentDay     time.Weekday
-	CurrentStep    int // 0 - ожидание дня, 1 - ожидание предметов, 2 - выбор недели
+	CurrentStep    int // 0 - ожидание дня, 1 - ожидание предметов, 2 - выбор недели, 3 - уточнение полей пары
 	ScheduleInput  map[time.Weekday][]domain.Lesson
 	CurrentWeekNum int // 1 - числитель, 2 - знаменатель
+	PendingLesson  *service.LessonDraft
 }
 
-var userStates = make(map[int64]*UserState)
+var userStates = newSessionStore[UserState]()
 
-// Добавляем в начало файла
+// getCurrentWeekNumber возвращает четность текущей недели: 1 - числитель, 2 - знаменатель
 func getCurrentWeekNumber() int {
-	_, week := time.Now().ISOWeek()
-	if week%2 == 0 {
-		return 2 // Знаменатель
-	}
-	return 1 // Числитель
+	return service.WeekParity(time.Now())
 }
 
 // RegisterHandlers регистрирует все обработчики команд бота
 func RegisterHandlers(b *bot.Bot, svc *service.Service, log logger.Logger) {
+	// Цепочки middleware по видам обработчиков. Восстановление после паники, логирование,
+	// метрики и сохранение профиля подключаются для всех апдейтов в app.
+	limiter := newUpdateLimiter(commandsPerMinute, commandsBurst)
+	user := []bot.Middleware{requireSender, throttle(svc, limiter)}
+	admin := []bot.Middleware{requireSender, adminOnly(svc)}
+	callback := []bot.Middleware{throttle(svc, limiter)}
+	inline := []bot.Middleware{throttle(svc, limiter)}
+
 	// Основные команды
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/start", bot.MatchTypeExact, startHandler(svc, log))
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/help", bot.MatchTypeExact, helpHandler(log))
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/setschedule", bot.MatchTypeExact, setScheduleHandler(svc, log))
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/schedule", bot.MatchTypeExact, scheduleHandler(svc, log))
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/ask", bot.MatchTypePrefix, askHandler(svc, log))
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/cancel", bot.MatchTypeExact, cancelHandler(log))
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/today", bot.MatchTypeExact, todayHandler(svc, log))
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/tomorrow", bot.MatchTypeExact, tomorrowHandler(svc, log))
-	b.RegisterHandler(bot.HandlerTypeMessageText, "/week", bot.MatchTypeExact, weekHandler(svc, log))
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/start", bot.MatchTypeExact, startHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/help", bot.MatchTypeExact, helpHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/setschedule", bot.MatchTypeExact, setScheduleHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/schedule", bot.MatchTypeExact, scheduleHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/ask", bot.MatchTypePrefix, askHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/cancel", bot.MatchTypeExact, cancelHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/today", bot.MatchTypeExact, todayHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/tomorrow", bot.MatchTypeExact, tomorrowHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/week", bot.MatchTypePrefix, weekHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/day", bot.MatchTypePrefix, dayHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/usage", bot.MatchTypeExact, usageHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/edit", bot.MatchTypeExact, editHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/bells", bot.MatchTypeExact, bellsHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/now", bot.MatchTypeExact, nowHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/timezone", bot.MatchTypePrefix, timezoneHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/teachers", bot.MatchTypeExact, teachersHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/teacher", bot.MatchTypePrefix, teacherHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/where", bot.MatchTypePrefix, whereHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/language", bot.MatchTypePrefix, languageHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/profile", bot.MatchTypeExact, profileHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/mydata", bot.MatchTypeExact, myDataHandler(svc, log), user...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/deleteme", bot.MatchTypeExact, deleteMeHandler(svc, log), user...)
+
+	// Команды администраторов
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/addteacher", bot.MatchTypePrefix, addTeacherHandler(svc, log), admin...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/editteacher", bot.MatchTypePrefix, editTeacherHandler(svc, log), admin...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/delteacher", bot.MatchTypePrefix, deleteTeacherHandler(svc, log), admin...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/loglevel", bot.MatchTypePrefix, logLevelHandler(svc, log), admin...)
+	b.RegisterHandler(bot.HandlerTypeMessageText, "/broadcast", bot.MatchTypePrefix, broadcastHandler(svc, log), admin...)
+
+	// Inline-кнопки
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "edit_", bot.MatchTypePrefix, editCallbackHandler(svc, log), callback...)
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "bells:", bot.MatchTypePrefix, bellsCallbackHandler(svc, log), callback...)
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "schedule_", bot.MatchTypePrefix, scheduleCallbackHandler(svc, log), callback...)
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "cal:", bot.MatchTypePrefix, calendarCallbackHandler(svc, log), callback...)
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "lang:", bot.MatchTypePrefix, languageCallbackHandler(svc, log), callback...)
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "deleteme:", bot.MatchTypePrefix, deleteMeCallbackHandler(svc, log), callback...)
+	b.RegisterHandler(bot.HandlerTypeCallbackQueryData, "broadcast:", bot.MatchTypePrefix, broadcastCallbackHandler(svc, log), adminOnly(svc))
+
+	// Inline-режим: @bot today, @bot пятница
+	b.RegisterHandlerMatchFunc(isInlineQuery, inlineQueryHandler(svc, log), inline...)
+
+	// Голосовые сообщения (до общего обработчика текста)
+	b.RegisterHandlerMatchFunc(isVoiceMessage, voiceHandler(svc, log), user...)
 
 	// Обработчик для любых текстовых сообщений (для пошагового ввода)
-	b.RegisterHandler(bot.HandlerTypeMessageText, "", bot.MatchTypeContains, textMessageHandler(svc, log))
+	b.RegisterHandler(bot.HandlerTypeMessageText, "", bot.MatchTypeContains, textMessageHandler(svc, log), user...)
 }
 
 // startHandler обрабатывает команду /start
 func startHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		userID := update.Message.From.ID
 		chatID := update.Message.Chat.ID
+		lang := userLang(ctx, svc, update.Message.From)
 
 		if err := svc.ProcessStartCommand(ctx, userID); err != nil {
 			log.Errorw("Failed to process start command", "error", err, "userID", userID)
-			sendErrorMessage(b, ctx, chatID, "Не удалось начать работу. Попробуйте позже.")
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "start.error"))
 			return
 		}
 
 		currentWeek := getCurrentWeekNumber()
-		weekName := "числитель"
-		if currentWeek == 2 {
-			weekName = "знаменатель"
-		}
+		msg := i18n.T(lang, "start.welcome", currentWeek, parityName(lang, currentWeek))
 
-		msg := fmt.Sprintf(`Привет! Я бот для управления расписанием. 
-Сейчас %d-я учебная неделя (%s).
-
-Основные команды:
-/setschedule - установить расписание
-/today - расписание на сегодня
-/tomorrow - расписание на завтра
-/week - расписание на неделю
-/ask [вопрос] - задать вопрос AI-помощнику`, currentWeek, weekName)
-
-		if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   msg,
 		}); err != nil {
@@ -89,19 +120,22 @@ func startHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 // setScheduleHandler начинает процесс ввода расписания
 func setScheduleHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		userID := update.Message.From.ID
 		chatID := update.Message.Chat.ID
 
+		unlock := dialogLocks.lock(userID)
 		// Инициализируем состояние пользователя
-		userStates[userID] = &UserState{
+		userStates.set(userID, &UserState{
 			ScheduleInput: make(map[time.Weekday][]domain.Lesson),
-		}
+		})
+		unlock()
 
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text: "📝 Введите расписание. Сначала укажите день недели (например, 'Понедельник')\n" +
-				"Или отправьте /cancel для отмены",
-			ReplyMarkup: weekdayKeyboard(),
+		lang := userLang(ctx, svc, update.Message.From)
+		err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID:      chatID,
+			Text:        i18n.T(lang, "wizard.intro"),
+			ReplyMarkup: weekdayKeyboard(lang),
 		})
 		if err != nil {
 			log.Errorw("Failed to send schedule instructions", "error", err)
@@ -110,16 +144,20 @@ func setScheduleHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc
 }
 
 // cancelHandler отменяет ввод расписания
-func cancelHandler(log logger.Logger) bot.HandlerFunc {
+func cancelHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		userID := update.Message.From.ID
 		chatID := update.Message.Chat.ID
 
-		delete(userStates, userID)
+		unlock := dialogLocks.lock(userID)
+		userStates.delete(userID)
+		editStates.delete(userID)
+		unlock()
 
-		_, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		err := sendMessage(ctx, b, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   "❌ Ввод расписания отменен",
+			Text:   i18n.T(userLang(ctx, svc, update.Message.From), "wizard.cancelled"),
 		})
 		if err != nil {
 			log.Errorw("Failed to send cancel message", "error", err)
@@ -127,210 +165,383 @@ func cancelHandler(log logger.Logger) bot.HandlerFunc {
 	}
 }
 
-// textMessageHandler обрабатывает текстовые сообщения при вводе расписания
+// textMessageHandler обрабатывает текстовые сообщения при вводе и редактировании расписания
 func textMessageHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		userID := update.Message.From.ID
 		chatID := update.Message.Chat.ID
-		text := update.Message.Text
 
-		state, exists := userStates[userID]
-		if !exists {
-			return // Не в режиме ввода расписания
+		lang := userLang(ctx, svc, update.Message.From)
+
+		unlock := dialogLocks.lock(userID)
+		defer unlock()
+
+		if handleEditInput(ctx, b, svc, log, lang, userID, chatID, update.Message.Text) {
+			return
 		}
+		handleScheduleInput(ctx, b, svc, log, lang, userID, chatID, update.Message.Text)
+	}
+}
 
-		switch state.CurrentStep {
-		case 0: // Ожидаем день недели
-			day, err := parseWeekday(text)
-			if err != nil {
-				_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-					ChatID:      chatID,
-					Text:        "Неверный день недели. Пожалуйста, выберите день из списка:",
-					ReplyMarkup: weekdayKeyboard(),
-				})
-				return
-			}
+// handleScheduleInput обрабатывает очередной шаг ввода расписания.
+// Возвращает false, если пользователь не находится в режиме ввода.
+// Вызывающий держит dialogLocks.lock(userID).
+func handleScheduleInput(ctx context.Context, b *bot.Bot, svc *service.Service, log logger.Logger, lang i18n.Lang, userID, chatID int64, text string) bool {
+	state, exists := userStates.get(userID)
+	if !exists {
+		return false // Не в режиме ввода расписания
+	}
 
-			state.CurrentDay = day
-			state.CurrentStep = 1
+	switch state.CurrentStep {
+	case 0: // Ожидаем день недели
+		day, ok := i18n.ParseWeekday(text)
+		if !ok {
+			_ = sendMessage(ctx, b, &bot.SendMessageParams{
+				ChatID:      chatID,
+				Text:        i18n.T(lang, "wizard.bad_day"),
+				ReplyMarkup: weekdayKeyboard(lang),
+			})
+			return true
+		}
+
+		state.CurrentDay = day
+		state.CurrentStep = 1
 
-			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+		_ = sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "wizard.lessons_prompt", i18n.Weekday(lang, day)),
+		})
+
+	case 1: // Ожидаем пары
+		if text == "/done" {
+			// Завершаем ввод для этого дня
+			_ = sendMessage(ctx, b, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text: fmt.Sprintf("📅 Введите пары для %s в формате:\n"+
-					"<Название> | <Начало> | <Конец> | <Аудитория> | <Преподаватель>\n\n"+
-					"Пример:\n"+
-					"Математика | 09:00 | 10:30 | Ауд. 101 | Иванов И.И.\n\n"+
-					"Когда закончите, отправьте /done", russianWeekday(day)),
+				Text:   i18n.T(lang, "wizard.day_done", i18n.Weekday(lang, state.CurrentDay)),
 			})
+			state.CurrentStep = 2 // Переходим к вопросу о продолжении
+			return true
+		}
 
-		case 1: // Ожидаем пары
-			if text == "/done" {
-				// Завершаем ввод для этого дня
-				_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-					ChatID: chatID,
-					Text: fmt.Sprintf("День %s сохранен. Хотите добавить еще один день? (да/нет)",
-						russianWeekday(state.CurrentDay)),
-				})
-				state.CurrentStep = 2 // Переходим к вопросу о продолжении
-				return
-			}
+		draft, err := svc.ParseLesson(ctx, userID, text)
+		if errors.Is(err, service.ErrUnknownSlot) {
+			_ = sendMessage(ctx, b, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   i18n.T(lang, "wizard.unknown_slot"),
+			})
+			return true
+		}
+		if err != nil {
+			_ = sendMessage(ctx, b, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   i18n.T(lang, "wizard.parse_failed"),
+			})
+			return true
+		}
 
-			lesson, err := parseLesson(text)
-			if err != nil {
-				_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-					ChatID: chatID,
-					Text:   "Неверный формат. Пожалуйста, используйте формат:\nНазвание | Начало | Конец | Аудитория | Преподаватель",
-				})
-				return
-			}
+		if !draft.Complete() {
+			state.PendingLesson = &draft
+			state.CurrentStep = 3
+			askMissingField(ctx, b, lang, chatID, draft.Missing[0])
+			return true
+		}
+
+		addLesson(ctx, b, lang, state, chatID, draft.Lesson)
+
+	case 3: // Уточняем недостающие поля пары
+		if text == "/done" {
+			// Недозаполненная пара отбрасывается
+			state.PendingLesson = nil
+			state.CurrentStep = 1
+			return handleScheduleInput(ctx, b, svc, log, lang, userID, chatID, text)
+		}
 
-			state.ScheduleInput[state.CurrentDay] = append(state.ScheduleInput[state.CurrentDay], lesson)
-			_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+		draft := state.PendingLesson
+		if err := draft.Fill(text); err != nil {
+			_ = sendMessage(ctx, b, &bot.SendMessageParams{
 				ChatID: chatID,
-				Text:   "✅ Пара добавлена. Введите следующую или /done для завершения",
+				Text:   i18n.T(lang, "answer.retry"),
 			})
+			askMissingField(ctx, b, lang, chatID, draft.Missing[0])
+			return true
+		}
+
+		if !draft.Complete() {
+			askMissingField(ctx, b, lang, chatID, draft.Missing[0])
+			return true
+		}
+
+		state.PendingLesson = nil
+		state.CurrentStep = 1
+		addLesson(ctx, b, lang, state, chatID, draft.Lesson)
+
+	case 2: // Ожидаем ответ на вопрос о продолжении
+		if i18n.IsYes(text) {
+			state.CurrentStep = 0
+			_ = sendMessage(ctx, b, &bot.SendMessageParams{
+				ChatID:      chatID,
+				Text:        i18n.T(lang, "wizard.next_day"),
+				ReplyMarkup: weekdayKeyboard(lang),
+			})
+		} else {
+			// Сохраняем расписание. Сохраненные дни убираются из ввода; если какой-то
+			// день не сохранился, пользователь остается в мастере, чтобы ввести его заново.
+			var report strings.Builder
+			rejected := false
+			for day := time.Sunday; day <= time.Saturday; day++ {
+				lessons, ok := state.ScheduleInput[day]
+				if !ok {
+					continue
+				}
+
+				issues, err := svc.SaveSchedule(ctx, userID, day, lessons)
+				var validationErr *service.ValidationError
+				if errors.As(err, &validationErr) {
+					rejected = true
+					delete(state.ScheduleInput, day)
+					report.WriteString(i18n.T(lang, "wizard.day_invalid", i18n.Weekday(lang, day)))
+					report.WriteString(formatIssues(lang, validationErr.Issues))
+					continue
+				}
+				if err != nil {
+					// Пары дня остаются во вводе и сохранятся при следующем завершении
+					rejected = true
+					log.Errorw("Failed to save schedule", "error", err, "userID", userID, "day", day)
+					report.WriteString(i18n.T(lang, "wizard.day_failed", i18n.Weekday(lang, day)))
+					continue
+				}
+
+				delete(state.ScheduleInput, day)
+				if len(issues) > 0 {
+					report.WriteString(i18n.T(lang, "wizard.day_warnings", i18n.Weekday(lang, day)))
+					report.WriteString(formatIssues(lang, issues))
+				}
+			}
 
-		case 2: // Ожидаем ответ на вопрос о продолжении
-			if strings.ToLower(text) == "да" {
+			if rejected {
 				state.CurrentStep = 0
-				_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
+				_ = sendMessage(ctx, b, &bot.SendMessageParams{
 					ChatID:      chatID,
-					Text:        "Выберите следующий день недели:",
-					ReplyMarkup: weekdayKeyboard(),
+					Text:        i18n.T(lang, "wizard.not_saved") + report.String() + i18n.T(lang, "wizard.reenter_day"),
+					ReplyMarkup: weekdayKeyboard(lang),
 				})
-			} else {
-				// Сохраняем расписание и завершаем
-				for day, lessons := range state.ScheduleInput {
-					svc.SaveSchedule(ctx, userID, day, lessons)
-				}
+				return true
+			}
 
-				delete(userStates, userID)
+			userStates.delete(userID)
 
-				_, _ = b.SendMessage(ctx, &bot.SendMessageParams{
-					ChatID: chatID,
-					Text:   "✅ Расписание успешно сохранено!",
-				})
+			text := i18n.T(lang, "wizard.saved")
+			if report.Len() > 0 {
+				text = i18n.T(lang, "wizard.saved_with_issues") + report.String()
 			}
+
+			_ = sendMessage(ctx, b, &bot.SendMessageParams{
+				ChatID: chatID,
+				Text:   text,
+			})
 		}
 	}
+
+	return true
 }
 
 // todayHandler обрабатывает команду /today
 func todayHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
-		handleDaySchedule(ctx, b, update, svc, log, time.Now().Weekday())
+		log := logger.FromContext(ctx, log)
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		handleDaySchedule(ctx, b, svc, log, lang, userID, update.Message.Chat.ID, svc.Now(ctx, userID))
 	}
 }
 
 // tomorrowHandler обрабатывает команду /tomorrow
 func tomorrowHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
-		handleDaySchedule(ctx, b, update, svc, log, time.Now().Add(24*time.Hour).Weekday())
+		log := logger.FromContext(ctx, log)
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		handleDaySchedule(ctx, b, svc, log, lang, userID, update.Message.Chat.ID, svc.Now(ctx, userID).AddDate(0, 0, 1))
 	}
 }
 
-// weekHandler обрабатывает команду /week
+// weekHandler обрабатывает команду /week ("/week next" - следующая неделя,
+// "/week image" - картинкой, аргументы можно сочетать)
 func weekHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		chatID := update.Message.Chat.ID
 		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		weekOffset := 0
+		asImage := false
+		for _, arg := range strings.Fields(strings.ToLower(strings.TrimPrefix(update.Message.Text, "/week"))) {
+			switch arg {
+			case "next", "след", "следующая":
+				weekOffset = 1
+			case "image", "img", "картинка", "картинкой":
+				asImage = true
+			default:
+				sendErrorMessage(b, ctx, chatID, i18n.T(lang, "week.usage"))
+				return
+			}
+		}
 
-		var sb strings.Builder
-		sb.WriteString("📅 Ваше расписание на неделю:\n\n")
+		if asImage {
+			sendWeekImage(ctx, b, svc, log, lang, userID, chatID, weekOffset)
+			return
+		}
+		sendWeekSchedule(ctx, b, svc, log, lang, userID, chatID, weekOffset)
+	}
+}
 
-		for day := time.Monday; day <= time.Friday; day++ {
-			lessons, err := svc.GetSchedule(ctx, userID, day)
-			if err != nil {
-				log.Errorw("Failed to get schedule", "error", err, "userID", userID, "day", day)
-				continue
-			}
+// sendWeekSchedule отправляет расписание на неделю со сдвигом weekOffset от текущей
+func sendWeekSchedule(ctx context.Context, b *bot.Bot, svc *service.Service, log logger.Logger, lang i18n.Lang, userID, chatID int64, weekOffset int) {
+	nav := models.InlineKeyboardButton{Text: i18n.T(lang, "week.next_button"), CallbackData: "schedule_nextweek"}
+	if weekOffset > 0 {
+		nav = models.InlineKeyboardButton{Text: i18n.T(lang, "week.current_button"), CallbackData: "schedule_week"}
+	}
 
-			sb.WriteString(fmt.Sprintf("📌 %s:\n", russianWeekday(day)))
+	if err := sendMessage(ctx, b, &bot.SendMessageParams{
+		ChatID:      chatID,
+		Text:        formatWeekSchedule(ctx, svc, log, lang, userID, weekOffset),
+		ParseMode:   models.ParseModeHTML,
+		ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{{nav}}},
+	}); err != nil {
+		log.Errorw("Failed to send week schedule", "error", err, "chatID", chatID)
+	}
+}
 
-			if len(lessons) == 0 {
-				sb.WriteString("    Пар нет\n\n")
-				continue
-			}
+// formatWeekSchedule формирует HTML-текст расписания на неделю со сдвигом weekOffset от текущей.
+// Суббота показывается, только если в ней есть пары (шестидневка).
+func formatWeekSchedule(ctx context.Context, svc *service.Service, log logger.Logger, lang i18n.Lang, userID int64, weekOffset int) string {
+	now := svc.Now(ctx, userID)
+	monday := now.AddDate(0, 0, -(int(now.Weekday())+6)%7+7*weekOffset)
 
-			for _, lesson := range lessons {
-				sb.WriteString(fmt.Sprintf("    🕒 %s-%s %s\n",
-					lesson.StartTime.Format("15:04"),
-					lesson.EndTime.Format("15:04"),
-					lesson.Name))
-			}
-			sb.WriteString("\n")
+	var sb strings.Builder
+	if weekOffset == 0 {
+		sb.WriteString(i18n.T(lang, "week.title"))
+	} else {
+		sb.WriteString(i18n.T(lang, "week.title_next"))
+	}
+
+	for offset := 0; offset < 6; offset++ {
+		date := monday.AddDate(0, 0, offset)
+		day := date.Weekday()
+
+		lessons, err := svc.LessonsOn(ctx, userID, date)
+		if err != nil {
+			log.Errorw("Failed to get schedule", "error", err, "userID", userID, "day", day)
+			continue
 		}
 
-		if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   sb.String(),
-		}); err != nil {
-			log.Errorw("Failed to send week schedule", "error", err, "chatID", chatID)
+		if day == time.Saturday && len(lessons) == 0 {
+			continue
+		}
+
+		sb.WriteString(fmt.Sprintf("📌 %s, %s:\n", bold(i18n.Weekday(lang, day)), date.Format("02.01")))
+
+		if holiday, ok := svc.Holiday(date); ok {
+			sb.WriteString(fmt.Sprintf("    🎉 %s\n\n", italic(holiday)))
+			continue
 		}
+
+		if len(lessons) == 0 {
+			sb.WriteString("    " + italic(i18n.T(lang, "week.no_lessons")) + "\n\n")
+			continue
+		}
+
+		for _, lesson := range lessons {
+			sb.WriteString(fmt.Sprintf("    🕒 %s %s\n",
+				timeRange(lesson.StartTime, lesson.EndTime), bold(lesson.Name)))
+		}
+		sb.WriteString("\n")
 	}
+
+	return sb.String()
 }
 
 // handleDaySchedule обрабатывает расписание на конкретный день
-func handleDaySchedule(ctx context.Context, b *bot.Bot, update *models.Update, svc *service.Service, log logger.Logger, day time.Weekday) {
-	chatID := update.Message.Chat.ID
-	userID := update.Message.From.ID
-
-	lessons, err := svc.GetSchedule(ctx, userID, day)
+func handleDaySchedule(ctx context.Context, b *bot.Bot, svc *service.Service, log logger.Logger, lang i18n.Lang, userID, chatID int64, date time.Time) {
+	text, err := formatDaySchedule(ctx, svc, log, lang, userID, date)
 	if err != nil {
-		log.Errorw("Failed to get schedule", "error", err, "userID", userID, "day", day)
-		sendErrorMessage(b, ctx, chatID, "Ошибка при получении расписания. Попробуйте позже.")
+		log.Errorw("Failed to get schedule", "error", err, "userID", userID, "day", date.Weekday())
+		sendErrorMessage(b, ctx, chatID, i18n.T(lang, "schedule.error"))
 		return
 	}
 
+	if err := sendMessage(ctx, b, &bot.SendMessageParams{
+		ChatID:    chatID,
+		Text:      text,
+		ParseMode: models.ParseModeHTML,
+	}); err != nil {
+		log.Errorw("Failed to send schedule message", "error", err, "chatID", chatID)
+	}
+}
+
+// formatDaySchedule формирует HTML-текст расписания на дату: праздник, "пар нет"
+// или список пар с номерами по звонкам и предупреждениями о переходах между корпусами
+func formatDaySchedule(ctx context.Context, svc *service.Service, log logger.Logger, lang i18n.Lang, userID int64, date time.Time) (string, error) {
+	day := date.Weekday()
+
+	if holiday, ok := svc.Holiday(date); ok {
+		return i18n.T(lang, "day.holiday", bold(i18n.Weekday(lang, day)), date.Format("02.01.2006"), italic(holiday)), nil
+	}
+
+	lessons, err := svc.LessonsOn(ctx, userID, date)
+	if err != nil {
+		return "", err
+	}
+
 	if len(lessons) == 0 {
-		if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
-			ChatID: chatID,
-			Text:   fmt.Sprintf("На %s пар нет 🎉", russianWeekday(day)),
-		}); err != nil {
-			log.Errorw("Failed to send empty schedule message", "error", err, "chatID", chatID)
-		}
-		return
+		return i18n.T(lang, "day.empty", bold(i18n.Weekday(lang, day)), date.Format("02.01")), nil
 	}
 
 	var sb strings.Builder
-	sb.WriteString(fmt.Sprintf("📅 Расписание на %s:\n\n", russianWeekday(day)))
+	sb.WriteString(i18n.T(lang, "day.title",
+		bold(i18n.Weekday(lang, day)), date.Format("02.01"), escapeHTML(parityName(lang, service.WeekParity(date)))))
+
+	bells, hasBells, err := svc.UserBellSchedule(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get bell schedule", "error", err, "userID", userID)
+	}
 
 	for i, lesson := range lessons {
-		sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, lesson.Name))
-		sb.WriteString(fmt.Sprintf("   🕒 %s - %s\n", lesson.StartTime.Format("15:04"), lesson.EndTime.Format("15:04")))
+		slot := lesson.Slot
+		if slot == 0 && hasBells {
+			slot = bells.SlotAt(lesson.StartTime)
+		}
+
+		if slot > 0 {
+			sb.WriteString(i18n.T(lang, "day.lesson_slot", i+1, bold(lesson.Name), slot))
+		} else {
+			sb.WriteString(fmt.Sprintf("%d. %s\n", i+1, bold(lesson.Name)))
+		}
+		sb.WriteString(fmt.Sprintf("   🕒 %s\n", timeRange(lesson.StartTime, lesson.EndTime)))
 		if lesson.Location != "" {
-			sb.WriteString(fmt.Sprintf("   🏫 %s\n", lesson.Location))
+			sb.WriteString(fmt.Sprintf("   🏫 %s\n", escapeHTML(lesson.Location)))
 		}
 		if lesson.Teacher != "" {
-			sb.WriteString(fmt.Sprintf("   👨‍🏫 %s\n", lesson.Teacher))
+			sb.WriteString(fmt.Sprintf("   👨‍🏫 %s\n", escapeHTML(lesson.Teacher)))
 		}
 		sb.WriteString("\n")
 	}
 
-	if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
-		ChatID: chatID,
-		Text:   sb.String(),
-	}); err != nil {
-		log.Errorw("Failed to send schedule message", "error", err, "chatID", chatID)
-	}
+	sb.WriteString(formatTransferWarnings(lang, svc.TransferWarnings(lessons), svc))
+
+	return sb.String(), nil
 }
 
 // helpHandler обрабатывает команду /help
-func helpHandler(log logger.Logger) bot.HandlerFunc {
+func helpHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		chatID := update.Message.Chat.ID
+		msg := i18n.T(userLang(ctx, svc, update.Message.From), "help.text")
 
-		msg := `ℹ️ Доступные команды:
-
-/start - Начало работы
-/help - Эта справка
-/setschedule - Установить расписание
-/today - Расписание на сегодня
-/tomorrow - Расписание на завтра
-/week - Расписание на неделю
-/ask [вопрос] - Задать вопрос AI-помощнику`
-
-		if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
 			ChatID: chatID,
 			Text:   msg,
 		}); err != nil {
@@ -342,26 +553,75 @@ func helpHandler(log logger.Logger) bot.HandlerFunc {
 // askHandler обрабатывает команду /ask
 func askHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
 		question := strings.TrimPrefix(update.Message.Text, "/ask ")
+		lang := userLang(ctx, svc, update.Message.From)
 
 		if question == "" {
-			sendErrorMessage(b, ctx, chatID, "Пожалуйста, задайте вопрос после команды /ask")
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "ask.empty"))
 			return
 		}
 
-		answer, err := svc.ProcessAIQuestion(ctx, question)
+		answerQuestion(ctx, b, svc, log, lang, userID, chatID, question)
+	}
+}
+
+// answerQuestion отправляет вопрос AI-помощнику и пересылает ответ пользователю
+func answerQuestion(ctx context.Context, b *bot.Bot, svc *service.Service, log logger.Logger, lang i18n.Lang, userID, chatID int64, question string) {
+	answer, err := svc.ProcessAIQuestion(ctx, userID, question)
+	sendAnswer(ctx, b, log, lang, userID, chatID, question, answer, err)
+}
+
+// sendAnswer отправляет ответ AI-помощника или сообщение об ошибке запроса
+func sendAnswer(ctx context.Context, b *bot.Bot, log logger.Logger, lang i18n.Lang, userID, chatID int64, question, answer string, err error) {
+	var limitErr *service.LimitError
+	if errors.As(err, &limitErr) {
+		log.Infow("AI request limited", "userID", userID, "reason", limitErr.Reason)
+		sendErrorMessage(b, ctx, chatID, limitMessage(lang, limitErr))
+		return
+	}
+	if err != nil {
+		log.Errorw("Failed to process AI question", "error", err, "question", question)
+		sendErrorMessage(b, ctx, chatID, i18n.T(lang, "ask.error"))
+		return
+	}
+
+	if err := sendMessage(ctx, b, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   answer,
+	}); err != nil {
+		log.Errorw("Failed to send AI answer", "error", err, "chatID", chatID)
+	}
+}
+
+// usageHandler обрабатывает команду /usage
+func usageHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+
+		lang := userLang(ctx, svc, update.Message.From)
+
+		usage, err := svc.GetAIUsage(ctx, userID)
 		if err != nil {
-			log.Errorw("Failed to process AI question", "error", err, "question", question)
-			sendErrorMessage(b, ctx, chatID, "Произошла ошибка при обработке вопроса.")
+			log.Errorw("Failed to get AI usage", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "usage.error"))
 			return
 		}
 
-		if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		msg := i18n.T(lang, "usage.text",
+			formatQuota(lang, usage.Day, usage.Limits.Daily),
+			formatQuota(lang, usage.Month, usage.Limits.Monthly),
+			formatRate(lang, usage.Limits.RatePerMinute))
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
 			ChatID: chatID,
-			Text:   answer,
+			Text:   msg,
 		}); err != nil {
-			log.Errorw("Failed to send AI answer", "error", err, "chatID", chatID)
+			log.Errorw("Failed to send usage message", "error", err, "chatID", chatID)
 		}
 	}
 }
@@ -369,23 +629,29 @@ func askHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 // scheduleHandler обрабатывает команду /schedule (показывает меню выбора)
 func scheduleHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
 		chatID := update.Message.Chat.ID
+		lang := userLang(ctx, svc, update.Message.From)
 
 		kb := &models.InlineKeyboardMarkup{
 			InlineKeyboard: [][]models.InlineKeyboardButton{
 				{
-					{Text: "Сегодня", CallbackData: "schedule_today"},
-					{Text: "Завтра", CallbackData: "schedule_tomorrow"},
+					{Text: i18n.T(lang, "schedule.today"), CallbackData: "schedule_today"},
+					{Text: i18n.T(lang, "schedule.tomorrow"), CallbackData: "schedule_tomorrow"},
 				},
 				{
-					{Text: "Вся неделя", CallbackData: "schedule_week"},
+					{Text: i18n.T(lang, "schedule.week"), CallbackData: "schedule_week"},
+					{Text: i18n.T(lang, "schedule.next_week"), CallbackData: "schedule_nextweek"},
+				},
+				{
+					{Text: i18n.T(lang, "schedule.pick_date"), CallbackData: calendarData(svc.Now(ctx, update.Message.From.ID))},
 				},
 			},
 		}
 
-		if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
 			ChatID:      chatID,
-			Text:        "Выберите период для просмотра расписания:",
+			Text:        i18n.T(lang, "schedule.menu"),
 			ReplyMarkup: kb,
 		}); err != nil {
 			log.Errorw("Failed to send schedule menu", "error", err, "chatID", chatID)
@@ -396,94 +662,122 @@ func scheduleHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
 // Вспомогательные функции
 
 // weekdayKeyboard создает клавиатуру с днями недели
-func weekdayKeyboard() *models.ReplyKeyboardMarkup {
+func weekdayKeyboard(lang i18n.Lang) *models.ReplyKeyboardMarkup {
+	button := func(day time.Weekday) models.KeyboardButton {
+		return models.KeyboardButton{Text: i18n.Weekday(lang, day)}
+	}
+
 	return &models.ReplyKeyboardMarkup{
 		Keyboard: [][]models.KeyboardButton{
-			{
-				{Text: "Понедельник"},
-				{Text: "Вторник"},
-				{Text: "Среда"},
-			},
-			{
-				{Text: "Четверг"},
-				{Text: "Пятница"},
-				{Text: "Суббота"},
-			},
-			{
-				{Text: "Воскресенье"},
-				{Text: "/cancel"},
-			},
+			{button(time.Monday), button(time.Tuesday), button(time.Wednesday)},
+			{button(time.Thursday), button(time.Friday), button(time.Saturday)},
+			{button(time.Sunday), {Text: "/cancel"}},
 		},
 		ResizeKeyboard:  true,
 		OneTimeKeyboard: true,
 	}
 }
 
-// parseWeekday преобразует текст в день недели
-func parseWeekday(text string) (time.Weekday, error) {
-	switch strings.ToLower(text) {
-	case "понедельник":
-		return time.Monday, nil
-	case "вторник":
-		return time.Tuesday, nil
-	case "среда":
-		return time.Wednesday, nil
-	case "четверг":
-		return time.Thursday, nil
-	case "пятница":
-		return time.Friday, nil
-	case "суббота":
-		return time.Saturday, nil
-	case "воскресенье":
-		return time.Sunday, nil
-	default:
-		return time.Sunday, fmt.Errorf("invalid weekday")
+// addLesson проверяет разобранную пару и добавляет ее к вводимому дню
+func addLesson(ctx context.Context, b *bot.Bot, lang i18n.Lang, state *UserState, chatID int64, lesson domain.Lesson) {
+	issues := service.ValidateLesson(lesson, state.ScheduleInput[state.CurrentDay])
+	if service.HasErrors(issues) {
+		_ = sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "wizard.lesson_rejected", formatIssues(lang, issues)),
+		})
+		return
+	}
+
+	state.ScheduleInput[state.CurrentDay] = append(state.ScheduleInput[state.CurrentDay], lesson)
+
+	text := i18n.T(lang, "wizard.lesson_added",
+		lesson.Name, lesson.StartTime.Format("15:04"), lesson.EndTime.Format("15:04"))
+	if len(issues) > 0 {
+		text += i18n.T(lang, "wizard.lesson_warnings") + formatIssues(lang, issues)
 	}
+
+	_ = sendMessage(ctx, b, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   text,
+	})
 }
 
-// parseLesson разбирает строку с информацией о паре
-func parseLesson(text string) (domain.Lesson, error) {
-	parts := strings.Split(text, "|")
-	if len(parts) != 5 {
-		return domain.Lesson{}, fmt.Errorf("invalid format")
+// formatIssues описывает проблемы расписания по одной на строку
+func formatIssues(lang i18n.Lang, issues []service.ValidationIssue) string {
+	var sb strings.Builder
+	for _, issue := range issues {
+		name := issue.Lesson.Name
+		switch issue.Kind {
+		case service.IssueInvertedTime:
+			sb.WriteString(i18n.T(lang, "issue.inverted_time",
+				name, issue.Lesson.EndTime.Format("15:04"), issue.Lesson.StartTime.Format("15:04")))
+		case service.IssueEmptyName:
+			sb.WriteString(i18n.T(lang, "issue.empty_name"))
+		case service.IssueOverlap:
+			sb.WriteString(i18n.T(lang, "issue.overlap",
+				name, issue.Other.Name, issue.Other.StartTime.Format("15:04"), issue.Other.EndTime.Format("15:04")))
+		case service.IssueTooLong:
+			sb.WriteString(i18n.T(lang, "issue.too_long", name, int(service.MaxLessonDuration.Hours())))
+		case service.IssueLongGap:
+			sb.WriteString(i18n.T(lang, "issue.long_gap",
+				name, issue.Other.Name, issue.Lesson.EndTime.Format("15:04"), issue.Other.StartTime.Format("15:04")))
+		}
 	}
+	return sb.String()
+}
 
-	startTime, err := time.Parse("15:04", strings.TrimSpace(parts[1]))
-	if err != nil {
-		return domain.Lesson{}, err
+// askMissingField спрашивает у пользователя недостающее поле пары
+func askMissingField(ctx context.Context, b *bot.Bot, lang i18n.Lang, chatID int64, field service.LessonField) {
+	_ = sendMessage(ctx, b, &bot.SendMessageParams{
+		ChatID: chatID,
+		Text:   i18n.T(lang, "field."+string(field)+".ask"),
+	})
+}
+
+// parityName возвращает название четности недели
+func parityName(lang i18n.Lang, parity int) string {
+	if parity == 2 {
+		return i18n.T(lang, "parity.even")
 	}
+	return i18n.T(lang, "parity.odd")
+}
 
-	endTime, err := time.Parse("15:04", strings.TrimSpace(parts[2]))
-	if err != nil {
-		return domain.Lesson{}, err
+// limitMessage формирует понятное пользователю сообщение о превышении лимита
+func limitMessage(lang i18n.Lang, err *service.LimitError) string {
+	switch err.Reason {
+	case service.LimitDaily:
+		return i18n.T(lang, "limit.daily")
+	case service.LimitMonthly:
+		return i18n.T(lang, "limit.monthly")
+	default:
+		seconds := int(err.RetryAfter.Round(time.Second).Seconds())
+		if seconds < 1 {
+			seconds = 1
+		}
+		return i18n.T(lang, "limit.rate", seconds)
 	}
+}
 
-	return domain.Lesson{
-		Name:      strings.TrimSpace(parts[0]),
-		StartTime: startTime,
-		EndTime:   endTime,
-		Location:  strings.TrimSpace(parts[3]),
-		Teacher:   strings.TrimSpace(parts[4]),
-	}, nil
+// formatQuota форматирует счетчик вместе с лимитом
+func formatQuota(lang i18n.Lang, used, limit int) string {
+	if limit <= 0 {
+		return i18n.T(lang, "usage.quota_unlimited", used)
+	}
+	return i18n.T(lang, "usage.quota", used, limit)
 }
 
-// russianWeekday переводит день недели на русский
-func russianWeekday(day time.Weekday) string {
-	days := map[time.Weekday]string{
-		time.Monday:    "Понедельник",
-		time.Tuesday:   "Вторник",
-		time.Wednesday: "Среда",
-		time.Thursday:  "Четверг",
-		time.Friday:    "Пятница",
-		time.Saturday:  "Суббота",
-		time.Sunday:    "Воскресенье",
-	}
-	return days[day]
+// formatRate форматирует ограничение частоты запросов
+func formatRate(lang i18n.Lang, perMinute int) string {
+	if perMinute <= 0 {
+		return i18n.T(lang, "usage.unlimited")
+	}
+	return i18n.T(lang, "usage.rate", perMinute)
 }
 
 // sendErrorMessage отправляет сообщение об ошибке
 func sendErrorMessage(b *bot.Bot, ctx context.Context, chatID int64, text string) {
-	if _, err := b.SendMessage(ctx, &bot.SendMessageParams{
+	if err := sendMessage(ctx, b, &bot.SendMessageParams{
 		ChatID: chatID,
 		Text:   "❌ " + text,
 	}); err != nil {
@@ -0,0 +1,90 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+func wizardLesson(name, start, end string) domain.Lesson {
+	s, _ := time.Parse("15:04", start)
+	e, _ := time.Parse("15:04", end)
+	return domain.Lesson{Name: name, StartTime: s, EndTime: e}
+}
+
+func TestWizardKeepsRejectedDay(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeBotAPI(t)
+	b := api.bot(t)
+	svc := newTestService(t, service.Options{})
+	log := logger.New("error")
+	const userID = 501
+
+	userStates.set(userID, &UserState{
+		CurrentStep: 2,
+		ScheduleInput: map[time.Weekday][]domain.Lesson{
+			time.Monday:  {wizardLesson("Математика", "08:30", "10:00")},
+			time.Tuesday: {wizardLesson("Физика", "12:00", "10:00")},
+		},
+	})
+	t.Cleanup(func() { userStates.delete(userID) })
+
+	handleScheduleInput(ctx, b, svc, log, i18n.Russian, userID, userID, "нет")
+
+	if _, err := svc.GetSchedule(ctx, userID, time.Monday); err != nil {
+		t.Errorf("valid day was not saved: %v", err)
+	}
+	if _, err := svc.GetSchedule(ctx, userID, time.Tuesday); err == nil {
+		t.Error("invalid day was saved")
+	}
+
+	state, ok := userStates.get(userID)
+	if !ok {
+		t.Fatal("user left the wizard although a day was rejected")
+	}
+	if state.CurrentStep != 0 || len(state.ScheduleInput) != 0 {
+		t.Errorf("state = step %d, input %v; want day selection with nothing pending", state.CurrentStep, state.ScheduleInput)
+	}
+
+	sent := api.sent("sendMessage")
+	if len(sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sent))
+	}
+	text := sent[0].params["text"]
+	if strings.Contains(text, i18n.T(i18n.Russian, "wizard.saved_with_issues")) ||
+		!strings.Contains(text, i18n.T(i18n.Russian, "wizard.day_invalid", i18n.Weekday(i18n.Russian, time.Tuesday))) {
+		t.Errorf("reply = %q, want the rejected day reported", text)
+	}
+}
+
+func TestWizardFinishesWhenAllDaysSaved(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeBotAPI(t)
+	b := api.bot(t)
+	svc := newTestService(t, service.Options{})
+	const userID = 502
+
+	userStates.set(userID, &UserState{
+		CurrentStep: 2,
+		ScheduleInput: map[time.Weekday][]domain.Lesson{
+			time.Monday: {wizardLesson("Математика", "08:30", "10:00"), wizardLesson("Физика", "09:30", "11:00")},
+		},
+	})
+	t.Cleanup(func() { userStates.delete(userID) })
+
+	handleScheduleInput(ctx, b, svc, logger.New("error"), i18n.Russian, userID, userID, "нет")
+
+	if _, ok := userStates.get(userID); ok {
+		t.Error("user stayed in the wizard after every day was saved")
+	}
+	sent := api.sent("sendMessage")
+	if len(sent) != 1 || !strings.HasPrefix(sent[0].params["text"], i18n.T(i18n.Russian, "wizard.saved_with_issues")) {
+		t.Errorf("reply = %v, want the overlap warning", sent)
+	}
+}
@@ -0,0 +1,39 @@
+package telegram
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// HTTPClient - HTTP-клиент для Bot API. Сетевые ошибки http.Client содержат
+// URL запроса, а в адресах Bot API есть токен бота; клиент убирает URL,
+// чтобы токен не попал в логи вместе с ошибкой отправки.
+type HTTPClient struct {
+	client *http.Client
+}
+
+// NewHTTPClient создает клиент с общим таймаутом запроса
+func NewHTTPClient(timeout time.Duration) *HTTPClient {
+	return &HTTPClient{client: &http.Client{Timeout: timeout}}
+}
+
+// Do выполняет запрос, убирая URL из ошибки
+func (c *HTTPClient) Do(req *http.Request) (*http.Response, error) {
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, redactURL(err)
+	}
+	return resp, nil
+}
+
+// redactURL убирает адрес запроса из ошибки HTTP-клиента
+func redactURL(err error) error {
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return fmt.Errorf("%s request failed: %w", urlErr.Op, urlErr.Err)
+	}
+	return err
+}
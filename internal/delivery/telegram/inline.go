@@ -0,0 +1,98 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// inlineCacheTime - сколько секунд Telegram может кэшировать ответ на inline-запрос
+const inlineCacheTime = 60
+
+// isInlineQuery проверяет, что апдейт - inline-запрос
+func isInlineQuery(update *models.Update) bool {
+	return update.InlineQuery != nil
+}
+
+// inlineQueryHandler отвечает на inline-запросы (@bot today, @bot пятница, @bot 21.10)
+// расписанием пользователя, которое можно отправить в любой чат
+func inlineQueryHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		query := update.InlineQuery
+		if query.From == nil {
+			return
+		}
+		userID := query.From.ID
+		lang := userLang(ctx, svc, query.From)
+		now := svc.Now(ctx, userID)
+
+		// Нераспознанный запрос - пустой список результатов
+		dates, _ := inlineDates(query.Query, now)
+
+		results := make([]models.InlineQueryResult, 0, len(dates))
+		for _, date := range dates {
+			text, err := formatDaySchedule(ctx, svc, log, lang, userID, date)
+			if err != nil {
+				log.Errorw("Failed to format inline schedule", "error", err, "userID", userID)
+				continue
+			}
+
+			results = append(results, &models.InlineQueryResultArticle{
+				ID:          "day:" + date.Format("2006-01-02"),
+				Title:       fmt.Sprintf("📅 %s, %s", i18n.Weekday(lang, date.Weekday()), date.Format("02.01")),
+				Description: firstLine(plainText(text)),
+				InputMessageContent: &models.InputTextMessageContent{
+					MessageText: text,
+					ParseMode:   models.ParseModeHTML,
+				},
+			})
+		}
+
+		if _, err := b.AnswerInlineQuery(ctx, &bot.AnswerInlineQueryParams{
+			InlineQueryID: query.ID,
+			Results:       results,
+			CacheTime:     inlineCacheTime,
+			IsPersonal:    true,
+		}); err != nil {
+			log.Errorw("Failed to answer inline query", "error", err, "userID", userID)
+		}
+	}
+}
+
+// inlineDates определяет даты по тексту inline-запроса.
+// Пустой запрос предлагает сегодня и завтра.
+func inlineDates(text string, now time.Time) ([]time.Time, error) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	switch text {
+	case "":
+		return []time.Time{today, today.AddDate(0, 0, 1)}, nil
+	case "today":
+		return []time.Time{today}, nil
+	case "tomorrow":
+		return []time.Time{today.AddDate(0, 0, 1)}, nil
+	}
+
+	date, err := parseDate(text, now)
+	if err != nil {
+		return nil, err
+	}
+	return []time.Time{date}, nil
+}
+
+// firstLine возвращает первую строку текста для описания inline-результата
+func firstLine(text string) string {
+	if i := strings.Index(text, "\n"); i >= 0 {
+		return text[:i]
+	}
+	return text
+}
@@ -0,0 +1,142 @@
+package telegram
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+func TestInlineDates(t *testing.T) {
+	// Понедельник
+	now := time.Date(2026, 10, 19, 21, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		query   string
+		want    []string
+		wantErr bool
+	}{
+		{query: "", want: []string{"2026-10-19", "2026-10-20"}},
+		{query: "  ", want: []string{"2026-10-19", "2026-10-20"}},
+		{query: "Today", want: []string{"2026-10-19"}},
+		{query: "tomorrow", want: []string{"2026-10-20"}},
+		{query: "завтра", want: []string{"2026-10-20"}},
+		{query: "пятница", want: []string{"2026-10-23"}},
+		{query: "21.10", want: []string{"2026-10-21"}},
+		{query: "расписание", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		dates, err := inlineDates(tt.query, now)
+		if tt.wantErr {
+			if err == nil {
+				t.Errorf("inlineDates(%q) = %v, want an error", tt.query, dates)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("inlineDates(%q): %v", tt.query, err)
+			continue
+		}
+
+		got := make([]string, 0, len(dates))
+		for _, d := range dates {
+			got = append(got, d.Format("2006-01-02"))
+		}
+		if strings.Join(got, ",") != strings.Join(tt.want, ",") {
+			t.Errorf("inlineDates(%q) = %v, want %v", tt.query, got, tt.want)
+		}
+	}
+}
+
+func TestFirstLine(t *testing.T) {
+	tests := map[string]string{
+		"Понедельник\n08:30 Математика": "Понедельник",
+		"одна строка":                   "одна строка",
+		"":                              "",
+	}
+	for text, want := range tests {
+		if got := firstLine(text); got != want {
+			t.Errorf("firstLine(%q) = %q, want %q", text, got, want)
+		}
+	}
+}
+
+// inlineResult - поля InlineQueryResultArticle, которые проверяет тест
+type inlineResult struct {
+	ID                  string `json:"id"`
+	Title               string `json:"title"`
+	Description         string `json:"description"`
+	InputMessageContent struct {
+		MessageText string `json:"message_text"`
+		ParseMode   string `json:"parse_mode"`
+	} `json:"input_message_content"`
+}
+
+func TestInlineQueryHandler(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t, service.Options{})
+	const userID = 701
+	if _, err := svc.SaveSchedule(ctx, userID, time.Wednesday, []domain.Lesson{wizardLesson("Математика", "08:30", "10:00")}); err != nil {
+		t.Fatal(err)
+	}
+	log := logger.New("error")
+
+	tests := []struct {
+		name  string
+		query string
+		want  []string // ID результатов
+	}{
+		{name: "date", query: "2026-10-21", want: []string{"day:2026-10-21"}},
+		{name: "unrecognized", query: "когда физика", want: []string{}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newFakeBotAPI(t)
+			api.respond("answerInlineQuery", func(map[string]string) (int, string) {
+				return http.StatusOK, `{"ok":true,"result":true}`
+			})
+			inlineQueryHandler(svc, log)(ctx, api.bot(t), &models.Update{InlineQuery: &models.InlineQuery{
+				ID:    "q1",
+				From:  &models.User{ID: userID, LanguageCode: "ru"},
+				Query: tt.query,
+			}})
+
+			answers := api.sent("answerInlineQuery")
+			if len(answers) != 1 {
+				t.Fatalf("answerInlineQuery called %d times, want 1", len(answers))
+			}
+			params := answers[0].params
+			if params["inline_query_id"] != "q1" || params["is_personal"] != "true" {
+				t.Errorf("params = %v, want a personal answer to q1", params)
+			}
+
+			var results []inlineResult
+			if err := json.Unmarshal([]byte(params["results"]), &results); err != nil {
+				t.Fatalf("results %q: %v", params["results"], err)
+			}
+			if len(results) != len(tt.want) {
+				t.Fatalf("got %d results, want %d", len(results), len(tt.want))
+			}
+			for i, r := range results {
+				if r.ID != tt.want[i] {
+					t.Errorf("result %d id = %q, want %q", i, r.ID, tt.want[i])
+				}
+				if !strings.Contains(r.Title, "21.10") || r.InputMessageContent.ParseMode != string(models.ParseModeHTML) {
+					t.Errorf("result %d = %+v", i, r)
+				}
+				if !strings.Contains(r.InputMessageContent.MessageText, "Математика") || strings.Contains(r.Description, "<") {
+					t.Errorf("result %d text = %q, description = %q", i, r.InputMessageContent.MessageText, r.Description)
+				}
+			}
+		})
+	}
+}
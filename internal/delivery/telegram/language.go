@@ -0,0 +1,109 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// userLang возвращает язык интерфейса отправителя апдейта
+func userLang(ctx context.Context, svc *service.Service, from *models.User) i18n.Lang {
+	if from == nil {
+		return i18n.Default
+	}
+	return svc.Language(ctx, from.ID, from.LanguageCode)
+}
+
+// languageHandler обрабатывает команду /language [ru|en].
+// Без аргумента показывает кнопки выбора языка.
+func languageHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		arg := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/language"))
+
+		if arg == "" {
+			if err := sendMessage(ctx, b, &bot.SendMessageParams{
+				ChatID:      chatID,
+				Text:        i18n.T(lang, "language.choose", lang.Name()),
+				ReplyMarkup: languageKeyboard(lang),
+			}); err != nil {
+				log.Errorw("Failed to send language menu", "error", err, "chatID", chatID)
+			}
+			return
+		}
+
+		selected, ok := i18n.Parse(arg)
+		if !ok {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "language.unknown"))
+			return
+		}
+
+		if err := svc.SetLanguage(ctx, userID, selected); err != nil {
+			log.Errorw("Failed to set language", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "language.error"))
+			return
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(selected, "language.saved", selected.Name()),
+		}); err != nil {
+			log.Errorw("Failed to confirm language", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// languageCallbackHandler сохраняет язык, выбранный кнопкой. Формат данных: lang:<код>
+func languageCallbackHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		query := update.CallbackQuery
+		userID := query.From.ID
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+
+		selected, ok := i18n.Parse(strings.TrimPrefix(query.Data, "lang:"))
+		if !ok {
+			return
+		}
+
+		if err := svc.SetLanguage(ctx, userID, selected); err != nil {
+			log.Errorw("Failed to set language", "error", err, "userID", userID)
+			return
+		}
+
+		msg := query.Message.Message
+		if msg == nil {
+			return
+		}
+
+		if _, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    msg.Chat.ID,
+			MessageID: msg.ID,
+			Text:      i18n.T(selected, "language.saved", selected.Name()),
+		}); err != nil {
+			log.Errorw("Failed to confirm language", "error", err, "chatID", msg.Chat.ID)
+		}
+	}
+}
+
+// languageKeyboard создает inline-клавиатуру выбора языка, текущий отмечен галочкой
+func languageKeyboard(current i18n.Lang) *models.InlineKeyboardMarkup {
+	row := make([]models.InlineKeyboardButton, 0, len(i18n.Supported()))
+	for _, lang := range i18n.Supported() {
+		label := lang.Name()
+		if lang == current {
+			label = "✅ " + label
+		}
+		row = append(row, models.InlineKeyboardButton{Text: label, CallbackData: "lang:" + string(lang)})
+	}
+	return &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{row}}
+}
@@ -0,0 +1,47 @@
+package telegram
+
+import (
+	"context"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// LoggingMiddleware кладет в контекст логгер с полями апдейта (update_id, user_id, chat_id, command).
+// Обработчики получают его через logger.FromContext.
+func LoggingMiddleware(log logger.Logger) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			fields := []interface{}{"update_id", update.ID, "command", updateLabel(update)}
+			userID, chatID := updateIDs(update)
+			if userID != 0 {
+				fields = append(fields, "user_id", userID)
+			}
+			if chatID != 0 {
+				fields = append(fields, "chat_id", chatID)
+			}
+
+			reqLog := log.With(fields...)
+			reqLog.Debugw("Handling update")
+			next(logger.WithContext(ctx, reqLog), b, update)
+		}
+	}
+}
+
+// updateIDs возвращает отправителя и чат апдейта (0, если их нет)
+func updateIDs(update *models.Update) (userID, chatID int64) {
+	if from := updateSender(update); from != nil {
+		userID = from.ID
+	}
+
+	switch {
+	case update.Message != nil:
+		chatID = update.Message.Chat.ID
+	case update.CallbackQuery != nil:
+		if msg := update.CallbackQuery.Message.Message; msg != nil {
+			chatID = msg.Chat.ID
+		}
+	}
+	return userID, chatID
+}
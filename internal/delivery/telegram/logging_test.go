@@ -0,0 +1,99 @@
+package telegram
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// fieldsLogger запоминает поля, переданные в With
+type fieldsLogger struct {
+	logger.Logger
+	fields []interface{}
+}
+
+func (l *fieldsLogger) With(keysAndValues ...interface{}) logger.Logger {
+	return &fieldsLogger{
+		Logger: l.Logger.With(keysAndValues...),
+		fields: append(append([]interface{}{}, l.fields...), keysAndValues...),
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	tests := []struct {
+		name   string
+		update *models.Update
+		want   []interface{}
+	}{
+		{
+			name:   "message",
+			update: &models.Update{ID: 7, Message: &models.Message{From: &models.User{ID: 1}, Chat: models.Chat{ID: 2}, Text: "/today"}},
+			want:   []interface{}{"update_id", int64(7), "command", "/today", "user_id", int64(1), "chat_id", int64(2)},
+		},
+		{
+			name:   "inline query without chat",
+			update: &models.Update{ID: 8, InlineQuery: &models.InlineQuery{From: &models.User{ID: 1}}},
+			want:   []interface{}{"update_id", int64(8), "command", "inline", "user_id", int64(1)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			base := &fieldsLogger{Logger: logger.New("error")}
+
+			var got logger.Logger
+			LoggingMiddleware(base)(func(ctx context.Context, b *bot.Bot, update *models.Update) {
+				got = logger.FromContext(ctx, nil)
+			})(context.Background(), nil, tt.update)
+
+			reqLog, ok := got.(*fieldsLogger)
+			if !ok {
+				t.Fatalf("handler got %T, want the request logger from the context", got)
+			}
+			if !reflect.DeepEqual(reqLog.fields, tt.want) {
+				t.Errorf("fields = %v, want %v", reqLog.fields, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogLevelHandler(t *testing.T) {
+	api := newFakeBotAPI(t)
+	b := api.bot(t)
+	svc := newTestService(t, service.Options{Admins: []int64{1}})
+	log := logger.New("info")
+	handler := logLevelHandler(svc, log)
+
+	handler(context.Background(), b, messageUpdate(1, "/loglevel DEBUG"))
+	if log.Level() != "debug" {
+		t.Fatalf("level = %s, want debug", log.Level())
+	}
+
+	handler(context.Background(), b, messageUpdate(1, "/loglevel verbose"))
+	if log.Level() != "debug" {
+		t.Fatalf("invalid level changed the level to %s", log.Level())
+	}
+
+	handler(context.Background(), b, messageUpdate(1, "/loglevel"))
+
+	sent := api.sent("sendMessage")
+	if len(sent) != 3 {
+		t.Fatalf("sent %d messages, want 3", len(sent))
+	}
+	for i, want := range []string{
+		i18n.T(i18n.Russian, "loglevel.set", "debug"),
+		i18n.T(i18n.Russian, "loglevel.invalid"),
+		i18n.T(i18n.Russian, "loglevel.current", "debug"),
+	} {
+		if !strings.Contains(sent[i].params["text"], want) {
+			t.Errorf("reply %d = %q, want %q", i, sent[i].params["text"], want)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// logLevelHandler обрабатывает команду администратора /loglevel [debug|info|warn|error]
+// (доступ проверяет adminOnly).
+// Без аргумента показывает текущий уровень.
+func logLevelHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		level := strings.ToLower(strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/loglevel")))
+		text := i18n.T(lang, "loglevel.current", log.Level())
+		if level != "" {
+			if err := log.SetLevel(level); err != nil {
+				sendErrorMessage(b, ctx, chatID, i18n.T(lang, "loglevel.invalid"))
+				return
+			}
+			log.Infow("Log level changed", "level", level)
+			text = i18n.T(lang, "loglevel.set", level)
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		}); err != nil {
+			log.Errorw("Failed to send log level", "error", err, "chatID", chatID)
+		}
+	}
+}
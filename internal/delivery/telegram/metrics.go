@@ -0,0 +1,72 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/metrics"
+)
+
+// knownCommands - команды, которые попадают в метки метрик как есть.
+// Остальные учитываются как unknown_command, чтобы число меток не росло.
+var knownCommands = map[string]bool{
+	"/start": true, "/help": true, "/setschedule": true, "/schedule": true, "/ask": true,
+	"/cancel": true, "/today": true, "/tomorrow": true, "/week": true, "/day": true,
+	"/usage": true, "/edit": true, "/bells": true, "/now": true, "/timezone": true,
+	"/teachers": true, "/teacher": true, "/where": true, "/language": true, "/profile": true,
+	"/mydata": true, "/deleteme": true,
+	"/addteacher": true, "/editteacher": true, "/delteacher": true, "/loglevel": true, "/broadcast": true,
+}
+
+// knownCallbacks - префиксы данных inline-кнопок
+var knownCallbacks = []string{"edit_", "bells:", "schedule_", "cal:", "lang:", "deleteme:", "broadcast:"}
+
+// MetricsMiddleware учитывает каждый апдейт и время его обработки
+func MetricsMiddleware(m *metrics.Metrics) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			start := time.Now()
+			next(ctx, b, update)
+			m.ObserveUpdate(updateLabel(update), time.Since(start))
+		}
+	}
+}
+
+// RegisterSessionMetrics добавляет датчики незавершенных пошаговых диалогов
+func RegisterSessionMetrics(m *metrics.Metrics) {
+	m.RegisterSessions("schedule_input", func() int { return userStates.len() })
+	m.RegisterSessions("edit", func() int { return editStates.len() })
+}
+
+// updateLabel определяет метку апдейта: команда, префикс кнопки или вид сообщения
+func updateLabel(update *models.Update) string {
+	switch {
+	case update.InlineQuery != nil:
+		return "inline"
+	case update.CallbackQuery != nil:
+		for _, prefix := range knownCallbacks {
+			if strings.HasPrefix(update.CallbackQuery.Data, prefix) {
+				return "callback:" + strings.TrimRight(prefix, "_:")
+			}
+		}
+		return "callback:unknown"
+	case update.Message != nil:
+		if update.Message.Voice != nil {
+			return "voice"
+		}
+		text := update.Message.Text
+		if !strings.HasPrefix(text, "/") {
+			return "text"
+		}
+		command, _, _ := strings.Cut(strings.Fields(text)[0], "@")
+		if knownCommands[command] {
+			return command
+		}
+		return "unknown_command"
+	default:
+		return "other"
+	}
+}
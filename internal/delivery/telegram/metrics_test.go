@@ -0,0 +1,66 @@
+package telegram
+
+import (
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/metrics"
+)
+
+func TestUpdateLabel(t *testing.T) {
+	tests := []struct {
+		name   string
+		update *models.Update
+		want   string
+	}{
+		{"known command", &models.Update{Message: &models.Message{Text: "/today"}}, "/today"},
+		{"command with args", &models.Update{Message: &models.Message{Text: "/week next"}}, "/week"},
+		{"command with bot name", &models.Update{Message: &models.Message{Text: "/help@test_bot"}}, "/help"},
+		{"unknown command", &models.Update{Message: &models.Message{Text: "/whatever"}}, "unknown_command"},
+		{"text", &models.Update{Message: &models.Message{Text: "понедельник"}}, "text"},
+		{"voice", &models.Update{Message: &models.Message{Voice: &models.Voice{}}}, "voice"},
+		{"callback", &models.Update{CallbackQuery: &models.CallbackQuery{Data: "edit_day_1"}}, "callback:edit"},
+		{"callback with colon", &models.Update{CallbackQuery: &models.CallbackQuery{Data: "lang:en"}}, "callback:lang"},
+		{"unknown callback", &models.Update{CallbackQuery: &models.CallbackQuery{Data: "zzz"}}, "callback:unknown"},
+		{"inline", &models.Update{InlineQuery: &models.InlineQuery{Query: "today"}}, "inline"},
+		{"other", &models.Update{}, "other"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := updateLabel(tt.update); got != tt.want {
+				t.Errorf("updateLabel = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSessionMetrics(t *testing.T) {
+	m := metrics.New()
+	RegisterSessionMetrics(m)
+
+	userStates.set(1001, &UserState{})
+	userStates.set(1002, &UserState{})
+	editStates.set(1001, &EditState{})
+	t.Cleanup(func() {
+		userStates.delete(1001)
+		userStates.delete(1002)
+		editStates.delete(1001)
+	})
+
+	rec := httptest.NewRecorder()
+	m.Handler().ServeHTTP(rec, httptest.NewRequest("GET", "/metrics", nil))
+	body, _ := io.ReadAll(rec.Body)
+
+	for _, want := range []string{
+		`tg_bot_active_sessions{kind="schedule_input"} 2`,
+		`tg_bot_active_sessions{kind="edit"} 1`,
+	} {
+		if !strings.Contains(string(body), want) {
+			t.Errorf("metrics have no %q", want)
+		}
+	}
+}
@@ -0,0 +1,213 @@
+package telegram
+
+import (
+	"context"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// Middleware делятся на общие для всех апдейтов (подключаются в app через bot.WithMiddlewares:
+// RecoverMiddleware, LoggingMiddleware, MetricsMiddleware, RegisterUserMiddleware) и цепочки
+// отдельных команд (requireSender, throttle, adminOnly), которые собираются в RegisterHandlers.
+
+// Ограничение частоты апдейтов от одного пользователя
+const (
+	commandsPerMinute = 30
+	commandsBurst     = 10
+)
+
+// RecoverMiddleware перехватывает панику обработчика: пишет ее в лог со стеком
+// и сообщает пользователю об ошибке, чтобы паника не уронила процесс
+func RecoverMiddleware(svc *service.Service, log logger.Logger) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			defer func() {
+				r := recover()
+				if r == nil {
+					return
+				}
+				logger.FromContext(ctx, log).Errorw("Handler panicked", "panic", r, "stack", string(debug.Stack()))
+
+				if _, chatID := updateIDs(update); chatID != 0 {
+					sendErrorMessage(b, ctx, chatID, i18n.T(userLang(ctx, svc, updateSender(update)), "error.internal"))
+				}
+			}()
+			next(ctx, b, update)
+		}
+	}
+}
+
+// requireSender пропускает только сообщения с отправителем и чатом.
+// Посты каналов и апдейты без сообщения обработчикам команд не передаются.
+func requireSender(next bot.HandlerFunc) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		if update.Message == nil || update.Message.From == nil {
+			return
+		}
+		next(ctx, b, update)
+	}
+}
+
+// RegisterUserMiddleware сохраняет отправителя каждого апдейта: при первом обращении
+// создает пользователя, затем обновляет username, имя, язык и время активности
+func RegisterUserMiddleware(svc *service.Service, log logger.Logger) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			if from := updateSender(update); from != nil && !from.IsBot {
+				if err := svc.TouchUser(ctx, domain.User{
+					ID:           from.ID,
+					Username:     from.Username,
+					FirstName:    from.FirstName,
+					LastName:     from.LastName,
+					LanguageCode: from.LanguageCode,
+				}); err != nil {
+					logger.FromContext(ctx, log).Errorw("Failed to save user profile", "error", err, "userID", from.ID)
+				}
+			}
+			next(ctx, b, update)
+		}
+	}
+}
+
+// adminOnly пропускает только администраторов, остальным отвечает отказом
+func adminOnly(svc *service.Service) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			from := updateSender(update)
+			if from == nil {
+				return
+			}
+			if svc.UserRole(from.ID) != domain.RoleAdmin {
+				if _, chatID := updateIDs(update); chatID != 0 {
+					sendErrorMessage(b, ctx, chatID, i18n.T(userLang(ctx, svc, from), "admin.only"))
+				}
+				return
+			}
+			next(ctx, b, update)
+		}
+	}
+}
+
+// throttle отбрасывает апдейты пользователя сверх лимита limiter.
+// О превышении пользователь узнает один раз, пока лимит не восстановится.
+func throttle(svc *service.Service, limiter *updateLimiter) bot.Middleware {
+	return func(next bot.HandlerFunc) bot.HandlerFunc {
+		return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+			from := updateSender(update)
+			if from == nil {
+				next(ctx, b, update)
+				return
+			}
+
+			allowed, warn := limiter.allow(from.ID)
+			if allowed {
+				next(ctx, b, update)
+				return
+			}
+			if !warn {
+				return
+			}
+
+			text := i18n.T(userLang(ctx, svc, from), "error.throttled")
+			if update.CallbackQuery != nil {
+				_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{
+					CallbackQueryID: update.CallbackQuery.ID,
+					Text:            text,
+				})
+				return
+			}
+			if _, chatID := updateIDs(update); chatID != 0 {
+				sendErrorMessage(b, ctx, chatID, text)
+			}
+		}
+	}
+}
+
+// updateLimiter - token bucket на каждого пользователя
+type updateLimiter struct {
+	mu       sync.Mutex
+	perToken time.Duration
+	burst    float64
+	buckets  map[int64]*updateBucket
+	now      func() time.Time
+}
+
+type updateBucket struct {
+	tokens float64
+	last   time.Time
+	warned bool
+}
+
+// maxLimiterBuckets - после этого числа пользователей полностью восстановившиеся корзины удаляются
+const maxLimiterBuckets = 10000
+
+func newUpdateLimiter(perMinute, burst int) *updateLimiter {
+	return &updateLimiter{
+		perToken: time.Minute / time.Duration(perMinute),
+		burst:    float64(burst),
+		buckets:  make(map[int64]*updateBucket),
+		now:      time.Now,
+	}
+}
+
+// allow списывает токен. warn = true, если апдейт отклонен впервые с момента исчерпания лимита.
+func (l *updateLimiter) allow(userID int64) (allowed, warn bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	now := l.now()
+	bucket, ok := l.buckets[userID]
+	if !ok {
+		if len(l.buckets) >= maxLimiterBuckets {
+			l.prune(now)
+		}
+		bucket = &updateBucket{tokens: l.burst, last: now}
+		l.buckets[userID] = bucket
+	}
+
+	bucket.tokens += float64(now.Sub(bucket.last)) / float64(l.perToken)
+	if bucket.tokens > l.burst {
+		bucket.tokens = l.burst
+	}
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		warn = !bucket.warned
+		bucket.warned = true
+		return false, warn
+	}
+	bucket.tokens--
+	bucket.warned = false
+	return true, false
+}
+
+// prune удаляет корзины, которые уже восстановились бы до полного лимита
+func (l *updateLimiter) prune(now time.Time) {
+	full := time.Duration(l.burst) * l.perToken
+	for id, bucket := range l.buckets {
+		if now.Sub(bucket.last) >= full {
+			delete(l.buckets, id)
+		}
+	}
+}
+
+// updateSender возвращает отправителя апдейта или nil
+func updateSender(update *models.Update) *models.User {
+	switch {
+	case update.Message != nil:
+		return update.Message.From
+	case update.CallbackQuery != nil:
+		return &update.CallbackQuery.From
+	case update.InlineQuery != nil:
+		return update.InlineQuery.From
+	}
+	return nil
+}
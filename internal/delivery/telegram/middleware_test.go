@@ -0,0 +1,234 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// messageUpdate создает апдейт с текстовым сообщением пользователя userID в его личном чате
+func messageUpdate(userID int64, text string) *models.Update {
+	return &models.Update{Message: &models.Message{
+		From: &models.User{ID: userID, LanguageCode: "ru"},
+		Chat: models.Chat{ID: userID},
+		Text: text,
+	}}
+}
+
+// testClock - управляемое время для updateLimiter
+type testClock struct{ now time.Time }
+
+func (c *testClock) Now() time.Time          { return c.now }
+func (c *testClock) Advance(d time.Duration) { c.now = c.now.Add(d) }
+
+func newTestLimiter(perMinute, burst int) (*updateLimiter, *testClock) {
+	clock := &testClock{now: time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)}
+	l := newUpdateLimiter(perMinute, burst)
+	l.now = clock.Now
+	return l, clock
+}
+
+func TestUpdateLimiterBurstAndRefill(t *testing.T) {
+	l, clock := newTestLimiter(60, 3)
+
+	for i := 0; i < 3; i++ {
+		if allowed, _ := l.allow(1); !allowed {
+			t.Fatalf("request %d within burst was rejected", i+1)
+		}
+	}
+	if allowed, _ := l.allow(1); allowed {
+		t.Fatal("request over burst was allowed")
+	}
+	if allowed, _ := l.allow(2); !allowed {
+		t.Fatal("another user shares the bucket")
+	}
+
+	// 60 в минуту - один токен в секунду
+	clock.Advance(500 * time.Millisecond)
+	if allowed, _ := l.allow(1); allowed {
+		t.Fatal("allowed before a token was refilled")
+	}
+	clock.Advance(500 * time.Millisecond)
+	if allowed, _ := l.allow(1); !allowed {
+		t.Fatal("rejected after a token was refilled")
+	}
+
+	// Простой не накапливает больше burst
+	clock.Advance(time.Hour)
+	for i := 0; i < 3; i++ {
+		if allowed, _ := l.allow(1); !allowed {
+			t.Fatalf("request %d after idle was rejected", i+1)
+		}
+	}
+	if allowed, _ := l.allow(1); allowed {
+		t.Fatal("idle time refilled more than burst")
+	}
+}
+
+func TestUpdateLimiterWarnsOnce(t *testing.T) {
+	l, clock := newTestLimiter(60, 1)
+
+	if allowed, warn := l.allow(1); !allowed || warn {
+		t.Fatalf("first request: allowed=%v warn=%v", allowed, warn)
+	}
+	if allowed, warn := l.allow(1); allowed || !warn {
+		t.Fatalf("first rejection: allowed=%v warn=%v, want a warning", allowed, warn)
+	}
+	for i := 0; i < 3; i++ {
+		if allowed, warn := l.allow(1); allowed || warn {
+			t.Fatalf("repeated rejection: allowed=%v warn=%v, want silence", allowed, warn)
+		}
+	}
+
+	// После восстановления лимита предупреждение снова возможно
+	clock.Advance(time.Second)
+	if allowed, _ := l.allow(1); !allowed {
+		t.Fatal("rejected after refill")
+	}
+	if _, warn := l.allow(1); !warn {
+		t.Fatal("no warning after the limit was exhausted again")
+	}
+}
+
+func TestUpdateLimiterPrune(t *testing.T) {
+	l, clock := newTestLimiter(60, 2)
+
+	for id := int64(0); id < maxLimiterBuckets; id++ {
+		l.allow(id)
+	}
+	// Корзина пользователя 0 еще не восстановилась: обновляем ее ближе к концу
+	clock.Advance(time.Second)
+	l.allow(0)
+	l.allow(0)
+
+	clock.Advance(time.Second)
+	l.allow(maxLimiterBuckets)
+
+	if n := len(l.buckets); n != 2 {
+		t.Fatalf("buckets after prune = %d, want 2 (the active user and the new one)", n)
+	}
+	// За секунду восстановился один токен, а не весь burst
+	if allowed, _ := l.allow(0); !allowed {
+		t.Fatal("refilled token was not available")
+	}
+	if allowed, _ := l.allow(0); allowed {
+		t.Fatal("prune reset the bucket of a user who has not refilled yet")
+	}
+}
+
+func TestRecoverMiddleware(t *testing.T) {
+	api := newFakeBotAPI(t)
+	b := api.bot(t)
+	svc := newTestService(t, service.Options{})
+
+	handler := RecoverMiddleware(svc, logger.New("error"))(func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		panic("boom")
+	})
+
+	func() {
+		defer func() {
+			if r := recover(); r != nil {
+				t.Fatalf("panic escaped the middleware: %v", r)
+			}
+		}()
+		handler(context.Background(), b, messageUpdate(5, "/today"))
+	}()
+
+	sent := api.sent("sendMessage")
+	if len(sent) != 1 {
+		t.Fatalf("sent %d messages, want 1", len(sent))
+	}
+	if sent[0].params["chat_id"] != "5" || !strings.Contains(sent[0].params["text"], i18n.T(i18n.Russian, "error.internal")) {
+		t.Errorf("error message = %v", sent[0].params)
+	}
+
+	// Без чата отвечать некуда, но паника все равно перехватывается
+	handler(context.Background(), b, &models.Update{InlineQuery: &models.InlineQuery{From: &models.User{ID: 5}}})
+	if n := len(api.sent("sendMessage")); n != 1 {
+		t.Errorf("sent %d messages for an update without a chat, want none", n-1)
+	}
+}
+
+func TestAdminOnly(t *testing.T) {
+	api := newFakeBotAPI(t)
+	b := api.bot(t)
+	svc := newTestService(t, service.Options{Admins: []int64{1}})
+
+	var reached []int64
+	handler := adminOnly(svc)(func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		reached = append(reached, update.Message.From.ID)
+	})
+
+	handler(context.Background(), b, messageUpdate(1, "/broadcast"))
+	handler(context.Background(), b, messageUpdate(2, "/broadcast"))
+	handler(context.Background(), b, &models.Update{})
+
+	if len(reached) != 1 || reached[0] != 1 {
+		t.Errorf("handler reached by %v, want only the admin", reached)
+	}
+
+	sent := api.sent("sendMessage")
+	if len(sent) != 1 {
+		t.Fatalf("sent %d messages, want a refusal to the non-admin only", len(sent))
+	}
+	if sent[0].params["chat_id"] != "2" || !strings.Contains(sent[0].params["text"], i18n.T(i18n.Russian, "admin.only")) {
+		t.Errorf("refusal = %v", sent[0].params)
+	}
+}
+
+func TestThrottle(t *testing.T) {
+	api := newFakeBotAPI(t)
+	b := api.bot(t)
+	svc := newTestService(t, service.Options{})
+	limiter, _ := newTestLimiter(60, 1)
+
+	calls := 0
+	handler := throttle(svc, limiter)(func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		calls++
+	})
+
+	for i := 0; i < 3; i++ {
+		handler(context.Background(), b, messageUpdate(1, "/today"))
+	}
+
+	if calls != 1 {
+		t.Errorf("handler called %d times, want 1", calls)
+	}
+	if sent := api.sent("sendMessage"); len(sent) != 1 || !strings.Contains(sent[0].params["text"], i18n.T(i18n.Russian, "error.throttled")) {
+		t.Errorf("throttle warnings = %v, want exactly one", sent)
+	}
+}
+
+func TestRegisterUserMiddleware(t *testing.T) {
+	ctx := context.Background()
+	svc := newTestService(t, service.Options{})
+	handler := RegisterUserMiddleware(svc, logger.New("error"))(func(ctx context.Context, b *bot.Bot, update *models.Update) {})
+
+	handler(ctx, nil, &models.Update{CallbackQuery: &models.CallbackQuery{
+		From: models.User{ID: 3, Username: "student", FirstName: "Иван", LanguageCode: "en"},
+	}})
+	handler(ctx, nil, &models.Update{Message: &models.Message{From: &models.User{ID: 4, IsBot: true}}})
+
+	profile, err := svc.Profile(ctx, 3)
+	if err != nil {
+		t.Fatalf("Profile: %v", err)
+	}
+	if u := profile.User; u.Username != "student" || u.FirstName != "Иван" || u.LanguageCode != "en" || u.LastSeen.IsZero() {
+		t.Errorf("sender was not saved: %+v", u)
+	}
+
+	botProfile, err := svc.Profile(ctx, 4)
+	if err != nil {
+		t.Fatalf("Profile: %v", err)
+	}
+	if !botProfile.User.LastSeen.IsZero() {
+		t.Error("a bot sender was registered")
+	}
+}
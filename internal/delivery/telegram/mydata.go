@@ -0,0 +1,107 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// myDataHandler обрабатывает команду /mydata - отправляет все данные пользователя JSON-файлом
+func myDataHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		data, err := svc.ExportUserData(ctx, userID)
+		if err != nil {
+			log.Errorw("Failed to export user data", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "mydata.error"))
+			return
+		}
+
+		payload, err := json.MarshalIndent(data, "", "  ")
+		if err != nil {
+			log.Errorw("Failed to encode user data", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "mydata.error"))
+			return
+		}
+
+		if _, err := b.SendDocument(ctx, &bot.SendDocumentParams{
+			ChatID:   chatID,
+			Document: &models.InputFileUpload{Filename: "mydata.json", Data: bytes.NewReader(payload)},
+			Caption:  i18n.T(lang, "mydata.caption"),
+		}); err != nil {
+			log.Errorw("Failed to send user data", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// deleteMeHandler обрабатывает команду /deleteme - просит подтвердить удаление данных
+func deleteMeHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "deleteme.confirm"),
+			ReplyMarkup: &models.InlineKeyboardMarkup{InlineKeyboard: [][]models.InlineKeyboardButton{{
+				{Text: i18n.T(lang, "deleteme.yes"), CallbackData: "deleteme:yes"},
+				{Text: i18n.T(lang, "deleteme.no"), CallbackData: "deleteme:no"},
+			}}},
+		}); err != nil {
+			log.Errorw("Failed to send delete confirmation", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// deleteMeCallbackHandler удаляет данные после подтверждения. Формат данных: deleteme:yes|no
+func deleteMeCallbackHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		query := update.CallbackQuery
+		userID := query.From.ID
+		lang := userLang(ctx, svc, &query.From)
+
+		_, _ = b.AnswerCallbackQuery(ctx, &bot.AnswerCallbackQueryParams{CallbackQueryID: query.ID})
+
+		msg := query.Message.Message
+		if msg == nil {
+			return
+		}
+
+		text := i18n.T(lang, "deleteme.cancelled")
+		if query.Data == "deleteme:yes" {
+			if err := svc.DeleteUserData(ctx, userID); err != nil {
+				log.Errorw("Failed to delete user data", "error", err, "userID", userID)
+				text = i18n.T(lang, "deleteme.error")
+			} else {
+				// Незавершенные пошаговые диалоги и неотправленные рассылки
+				// тоже содержат данные пользователя
+				unlock := dialogLocks.lock(userID)
+				userStates.delete(userID)
+				editStates.delete(userID)
+				unlock()
+				dropPendingBroadcast(userID)
+				text = i18n.T(i18n.FromCode(query.From.LanguageCode), "deleteme.done")
+			}
+		}
+
+		if _, err := b.EditMessageText(ctx, &bot.EditMessageTextParams{
+			ChatID:    msg.Chat.ID,
+			MessageID: msg.ID,
+			Text:      text,
+		}); err != nil {
+			log.Errorw("Failed to confirm data deletion", "error", err, "chatID", msg.Chat.ID)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package telegram
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+func TestDeleteMeClearsPendingState(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeBotAPI(t)
+	svc := newTestService(t, service.Options{})
+	const userID = 601
+
+	if err := svc.TouchUser(ctx, domain.User{ID: userID}); err != nil {
+		t.Fatal(err)
+	}
+	userStates.set(userID, &UserState{CurrentStep: 1})
+	editStates.set(userID, &EditState{Day: time.Monday})
+	pendingBroadcasts.Lock()
+	pendingBroadcasts.drafts[userID] = broadcastDraft{text: "Пары отменены"}
+	pendingBroadcasts.Unlock()
+	t.Cleanup(func() {
+		userStates.delete(userID)
+		editStates.delete(userID)
+		dropPendingBroadcast(userID)
+	})
+
+	deleteMeCallbackHandler(svc, logger.New("error"))(ctx, api.bot(t), &models.Update{CallbackQuery: &models.CallbackQuery{
+		ID:   "1",
+		From: models.User{ID: userID, LanguageCode: "ru"},
+		Data: "deleteme:yes",
+		Message: models.MaybeInaccessibleMessage{
+			Type:    models.MaybeInaccessibleMessageTypeMessage,
+			Message: &models.Message{ID: 1, Chat: models.Chat{ID: userID}},
+		},
+	}})
+
+	if _, ok := userStates.get(userID); ok {
+		t.Error("schedule wizard state survived delete")
+	}
+	if _, ok := editStates.get(userID); ok {
+		t.Error("edit state survived delete")
+	}
+	pendingBroadcasts.Lock()
+	_, ok := pendingBroadcasts.drafts[userID]
+	pendingBroadcasts.Unlock()
+	if ok {
+		t.Error("pending broadcast survived delete")
+	}
+}
@@ -0,0 +1,136 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// nowHandler обрабатывает команду /now (текущая и следующая пара)
+func nowHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		status, err := svc.GetLiveStatus(ctx, userID)
+		if err != nil {
+			log.Errorw("Failed to get live status", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "schedule.error"))
+			return
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   formatLiveStatus(lang, status),
+		}); err != nil {
+			log.Errorw("Failed to send live status", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// timezoneHandler обрабатывает команду /timezone <IANA-пояс>
+func timezoneHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		name := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/timezone"))
+
+		if name == "" {
+			text := i18n.T(lang, "timezone.current", svc.Location(ctx, userID))
+			if err := sendMessage(ctx, b, &bot.SendMessageParams{ChatID: chatID, Text: text}); err != nil {
+				log.Errorw("Failed to send time zone", "error", err, "chatID", chatID)
+			}
+			return
+		}
+
+		err := svc.SetTimeZone(ctx, userID, name)
+		if errors.Is(err, service.ErrUnknownTimeZone) {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "timezone.unknown"))
+			return
+		}
+		if err != nil {
+			log.Errorw("Failed to set time zone", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "timezone.error"))
+			return
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "timezone.saved", name),
+		}); err != nil {
+			log.Errorw("Failed to confirm time zone", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// formatLiveStatus описывает текущую пару, перерыв и следующую пару
+func formatLiveStatus(lang i18n.Lang, status service.LiveStatus) string {
+	var sb strings.Builder
+
+	if status.Current != nil {
+		sb.WriteString(i18n.T(lang, "now.current", status.Current.Name))
+		sb.WriteString(i18n.T(lang, "now.current_ends",
+			formatMinutes(lang, status.CurrentEnds.Sub(status.Now)), status.CurrentEnds.Format("15:04")))
+		writeLessonPlace(&sb, *status.Current)
+	} else {
+		sb.WriteString(i18n.T(lang, "now.no_current"))
+	}
+
+	if status.Next == nil {
+		sb.WriteString(i18n.T(lang, "now.no_next"))
+		return sb.String()
+	}
+
+	sb.WriteString(i18n.T(lang, "now.next", status.Next.Name))
+
+	sameDay := status.NextStarts.YearDay() == status.Now.YearDay() && status.NextStarts.Year() == status.Now.Year()
+	if sameDay {
+		sb.WriteString(i18n.T(lang, "now.next_today",
+			status.NextStarts.Format("15:04"), formatMinutes(lang, status.NextStarts.Sub(status.Now))))
+	} else {
+		sb.WriteString(i18n.T(lang, "now.next_later",
+			i18n.Weekday(lang, status.NextStarts.Weekday()), status.NextStarts.Format("02.01"), status.NextStarts.Format("15:04")))
+	}
+	writeLessonPlace(&sb, *status.Next)
+
+	if status.Current != nil && sameDay {
+		sb.WriteString(i18n.T(lang, "now.break", formatMinutes(lang, status.NextStarts.Sub(status.CurrentEnds))))
+	}
+
+	return sb.String()
+}
+
+// writeLessonPlace дописывает аудиторию и преподавателя пары
+func writeLessonPlace(sb *strings.Builder, lesson domain.Lesson) {
+	if lesson.Location != "" {
+		sb.WriteString(fmt.Sprintf("   🏫 %s\n", lesson.Location))
+	}
+	if lesson.Teacher != "" {
+		sb.WriteString(fmt.Sprintf("   👨‍🏫 %s\n", lesson.Teacher))
+	}
+}
+
+// formatMinutes форматирует длительность как "1 ч 15 мин"
+func formatMinutes(lang i18n.Lang, d time.Duration) string {
+	minutes := int(d.Round(time.Minute).Minutes())
+	if minutes < 60 {
+		return i18n.T(lang, "duration.minutes", minutes)
+	}
+	if minutes%60 == 0 {
+		return i18n.T(lang, "duration.hours", minutes/60)
+	}
+	return i18n.T(lang, "duration.hours_minutes", minutes/60, minutes%60)
+}
@@ -0,0 +1,89 @@
+package telegram
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// profileHandler обрабатывает команду /profile - показывает сохраненные данные пользователя
+func profileHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		profile, err := svc.Profile(ctx, userID)
+		if err != nil {
+			log.Errorw("Failed to get profile", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "profile.error"))
+			return
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID:    chatID,
+			Text:      formatProfile(lang, profile, svc.Location(ctx, userID)),
+			ParseMode: models.ParseModeHTML,
+		}); err != nil {
+			log.Errorw("Failed to send profile", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// formatProfile форматирует профиль, время выводится в часовом поясе пользователя
+func formatProfile(lang i18n.Lang, p service.Profile, loc *time.Location) string {
+	orEmpty := func(s string) string {
+		if s == "" {
+			return i18n.T(lang, "profile.empty")
+		}
+		return escapeHTML(s)
+	}
+	formatTime := func(t time.Time) string {
+		if t.IsZero() {
+			return i18n.T(lang, "profile.empty")
+		}
+		return t.In(loc).Format("02.01.2006 15:04")
+	}
+
+	username := ""
+	if p.User.Username != "" {
+		username = "@" + p.User.Username
+	}
+
+	botLang := i18n.T(lang, "profile.empty")
+	if selected, ok := i18n.Parse(p.User.Language); ok {
+		botLang = selected.Name()
+	}
+
+	role := i18n.T(lang, "profile.role_user")
+	if p.Role == domain.RoleAdmin {
+		role = i18n.T(lang, "profile.role_admin")
+	}
+
+	var sb strings.Builder
+	sb.WriteString(bold(i18n.T(lang, "profile.title")) + "\n\n")
+	for _, line := range []string{
+		i18n.T(lang, "profile.id", mono(strconv.FormatInt(p.User.ID, 10))),
+		i18n.T(lang, "profile.username", orEmpty(username)),
+		i18n.T(lang, "profile.name", orEmpty(strings.TrimSpace(p.User.FirstName+" "+p.User.LastName))),
+		i18n.T(lang, "profile.tg_lang", orEmpty(p.User.LanguageCode)),
+		i18n.T(lang, "profile.lang", botLang),
+		i18n.T(lang, "profile.role", role),
+		i18n.T(lang, "profile.timezone", orEmpty(p.TimeZone)),
+		i18n.T(lang, "profile.bells", orEmpty(p.BellSchedule)),
+		i18n.T(lang, "profile.created", formatTime(p.User.CreatedAt)),
+		i18n.T(lang, "profile.last_seen", formatTime(p.User.LastSeen)),
+	} {
+		sb.WriteString(line + "\n")
+	}
+	return sb.String()
+}
@@ -0,0 +1,60 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// whereHandler обрабатывает команду /where <аудитория> и присылает точку на карте
+func whereHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		query := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/where"))
+
+		if query == "" {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "where.usage"))
+			return
+		}
+
+		room, ok := svc.FindRoom(query)
+		if !ok {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "where.not_found"))
+			return
+		}
+
+		title := i18n.T(lang, "where.title", room.Code, room.Floor)
+		if room.Building.Name != "" {
+			title += " - " + room.Building.Name
+		}
+
+		if _, err := b.SendVenue(ctx, &bot.SendVenueParams{
+			ChatID:    chatID,
+			Latitude:  room.Building.Latitude,
+			Longitude: room.Building.Longitude,
+			Title:     title,
+			Address:   room.Building.Address,
+		}); err != nil {
+			log.Errorw("Failed to send room venue", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// formatTransferWarnings описывает переходы между корпусами с коротким перерывом (HTML)
+func formatTransferWarnings(lang i18n.Lang, warnings []service.TransferWarning, svc *service.Service) string {
+	var sb strings.Builder
+	for _, w := range warnings {
+		from, _ := svc.FindRoom(w.From.Location)
+		to, _ := svc.FindRoom(w.To.Location)
+		sb.WriteString(i18n.T(lang, "transfer.warning",
+			bold(w.From.Name), escapeHTML(from.Building.Name), escapeHTML(to.Building.Name), formatMinutes(lang, w.Break)))
+	}
+	return sb.String()
+}
@@ -0,0 +1,152 @@
+package telegram
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+)
+
+// maxMessageLength - ограничение Telegram на длину текста сообщения (в UTF-16)
+const maxMessageLength = 4096
+
+// htmlOpenTag находит открывающие и закрывающие теги HTML-разметки
+var htmlOpenTag = regexp.MustCompile(`<(/?)([a-zA-Z-]+)[^>]*>`)
+
+// sendMessage отправляет сообщение, при необходимости разбивая длинный текст
+// на несколько по границам абзацев и строк. Клавиатура прикрепляется к последней части.
+func sendMessage(ctx context.Context, b *bot.Bot, params *bot.SendMessageParams) error {
+	parts := splitMessage(params.Text, maxMessageLength, params.ParseMode == models.ParseModeHTML)
+
+	for i, part := range parts {
+		p := *params
+		p.Text = part
+		if i < len(parts)-1 {
+			p.ReplyMarkup = nil
+		}
+		if _, err := b.SendMessage(ctx, &p); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// splitMessage делит текст на части не длиннее limit. Для HTML открытые на месте
+// разреза теги закрываются в конце части и открываются заново в начале следующей.
+func splitMessage(text string, limit int, html bool) []string {
+	if textLength(text) <= limit {
+		return []string{text}
+	}
+
+	// Запас на закрывающие теги в конце части
+	reserve := 0
+	if html {
+		reserve = 64
+	}
+
+	var parts []string
+	var reopen string
+	for text != "" {
+		cut := cutIndex(text, limit-reserve-textLength(reopen), html)
+		chunk := reopen + text[:cut]
+		text = strings.TrimLeft(text[cut:], "\n")
+
+		if html {
+			open := openTags(chunk)
+			for i := len(open) - 1; i >= 0; i-- {
+				chunk += "</" + tagName(open[i]) + ">"
+			}
+			reopen = strings.Join(open, "")
+		}
+
+		if strings.TrimSpace(chunk) != "" {
+			parts = append(parts, strings.TrimRight(chunk, "\n"))
+		}
+	}
+	return parts
+}
+
+// cutIndex выбирает место разреза не дальше budget символов: по пустой строке,
+// переводу строки, пробелу или, если их нет, по границе символа
+func cutIndex(text string, budget int, html bool) int {
+	if budget < 1 {
+		budget = 1
+	}
+
+	end, length := 0, 0
+	for i, r := range text {
+		length += utf16.RuneLen(r)
+		if length > budget {
+			break
+		}
+		end = i + utf8.RuneLen(r)
+	}
+	if end == len(text) {
+		return end
+	}
+	if end == 0 {
+		_, size := utf8.DecodeRuneInString(text)
+		return size
+	}
+
+	cut := end
+	for _, sep := range []string{"\n\n", "\n", " "} {
+		// Слишком короткие части хуже, чем разрез внутри строки
+		if i := strings.LastIndex(text[:end], sep); i > end/2 {
+			cut = i + len(sep)
+			break
+		}
+	}
+
+	if html {
+		// Нельзя резать внутри тега или сущности вроде &amp;
+		if lt := strings.LastIndex(text[:cut], "<"); lt > strings.LastIndex(text[:cut], ">") && lt > 0 {
+			cut = lt
+		}
+		if amp := strings.LastIndex(text[:cut], "&"); amp > strings.LastIndex(text[:cut], ";") && amp > 0 {
+			cut = amp
+		}
+	}
+
+	return cut
+}
+
+// openTags возвращает теги, оставшиеся открытыми в конце фрагмента, в порядке открытия
+func openTags(chunk string) []string {
+	var stack []string
+	for _, m := range htmlOpenTag.FindAllStringSubmatch(chunk, -1) {
+		if m[1] == "" {
+			stack = append(stack, m[0])
+			continue
+		}
+		for i := len(stack) - 1; i >= 0; i-- {
+			if tagName(stack[i]) == strings.ToLower(m[2]) {
+				stack = append(stack[:i], stack[i+1:]...)
+				break
+			}
+		}
+	}
+	return stack
+}
+
+// tagName возвращает имя открывающего тега: "<a href=...>" -> "a"
+func tagName(tag string) string {
+	m := htmlOpenTag.FindStringSubmatch(tag)
+	if m == nil {
+		return ""
+	}
+	return strings.ToLower(m[2])
+}
+
+// textLength считает длину текста так же, как Telegram, - в кодовых единицах UTF-16
+func textLength(text string) int {
+	length := 0
+	for _, r := range text {
+		length += utf16.RuneLen(r)
+	}
+	return length
+}
@@ -0,0 +1,87 @@
+package telegram
+
+import "sync"
+
+// sessionStore хранит состояния пошаговых диалогов по ID пользователя.
+// Апдейты обрабатываются в отдельных горутинах, поэтому карта защищена мьютексом.
+type sessionStore[T any] struct {
+	mu     sync.Mutex
+	states map[int64]*T
+}
+
+func newSessionStore[T any]() *sessionStore[T] {
+	return &sessionStore[T]{states: make(map[int64]*T)}
+}
+
+func (s *sessionStore[T]) get(userID int64) (*T, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	state, ok := s.states[userID]
+	return state, ok
+}
+
+func (s *sessionStore[T]) set(userID int64, state *T) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.states[userID] = state
+}
+
+func (s *sessionStore[T]) delete(userID int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.states, userID)
+}
+
+// len возвращает число незавершенных диалогов
+func (s *sessionStore[T]) len() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.states)
+}
+
+// userLocks выдает мьютекс на пользователя. Хранилище защищает только карту,
+// а сами состояния диалога меняются обработчиком по шагам, поэтому обработчик
+// держит блокировку пользователя все время, пока работает с его диалогом.
+// Текст и голосовое сообщение одного пользователя обрабатываются по очереди,
+// а диалоги разных пользователей друг друга не ждут.
+type userLocks struct {
+	mu    sync.Mutex
+	locks map[int64]*userLock
+}
+
+type userLock struct {
+	sync.Mutex
+	refs int // Сколько обработчиков держат или ждут блокировку
+}
+
+func newUserLocks() *userLocks {
+	return &userLocks{locks: make(map[int64]*userLock)}
+}
+
+// lock блокирует диалоги пользователя и возвращает функцию разблокировки.
+// Мьютекс удаляется из карты, когда его больше никто не ждет.
+func (l *userLocks) lock(userID int64) (unlock func()) {
+	l.mu.Lock()
+	ul, ok := l.locks[userID]
+	if !ok {
+		ul = &userLock{}
+		l.locks[userID] = ul
+	}
+	ul.refs++
+	l.mu.Unlock()
+
+	ul.Lock()
+	return func() {
+		ul.Unlock()
+
+		l.mu.Lock()
+		defer l.mu.Unlock()
+		ul.refs--
+		if ul.refs == 0 {
+			delete(l.locks, userID)
+		}
+	}
+}
+
+// dialogLocks упорядочивает работу с userStates и editStates одного пользователя
+var dialogLocks = newUserLocks()
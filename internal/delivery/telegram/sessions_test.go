@@ -0,0 +1,142 @@
+package telegram
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+func TestSessionStore(t *testing.T) {
+	s := newSessionStore[EditState]()
+
+	if _, ok := s.get(1); ok {
+		t.Fatal("empty store returned a state")
+	}
+
+	s.set(1, &EditState{LessonID: "a"})
+	s.set(2, &EditState{LessonID: "b"})
+	if got, ok := s.get(1); !ok || got.LessonID != "a" {
+		t.Fatalf("get(1) = %v, %v", got, ok)
+	}
+	if n := s.len(); n != 2 {
+		t.Fatalf("len = %d, want 2", n)
+	}
+
+	s.delete(1)
+	if _, ok := s.get(1); ok {
+		t.Fatal("state survived delete")
+	}
+	if n := s.len(); n != 1 {
+		t.Fatalf("len after delete = %d, want 1", n)
+	}
+}
+
+// Запускается с -race: обработчики и /metrics обращаются к хранилищу из разных горутин
+func TestSessionStoreConcurrent(t *testing.T) {
+	s := newSessionStore[UserState]()
+
+	var wg sync.WaitGroup
+	for i := int64(0); i < 32; i++ {
+		wg.Add(1)
+		go func(userID int64) {
+			defer wg.Done()
+			s.set(userID, &UserState{})
+			s.get(userID)
+			s.len()
+			s.delete(userID)
+		}(i)
+	}
+	wg.Wait()
+
+	if n := s.len(); n != 0 {
+		t.Fatalf("len = %d, want 0", n)
+	}
+}
+
+func TestUserLocksSerializeOneUser(t *testing.T) {
+	l := newUserLocks()
+
+	var (
+		wg      sync.WaitGroup
+		counter int // Без блокировки -race сообщит о гонке
+	)
+	for i := 0; i < 32; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			unlock := l.lock(1)
+			defer unlock()
+			counter++
+		}()
+	}
+	wg.Wait()
+
+	if counter != 32 {
+		t.Errorf("counter = %d, want 32", counter)
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if len(l.locks) != 0 {
+		t.Errorf("%d locks left after all handlers finished", len(l.locks))
+	}
+}
+
+func TestUserLocksIndependentUsers(t *testing.T) {
+	l := newUserLocks()
+	unlock := l.lock(1)
+	defer unlock()
+
+	done := make(chan struct{})
+	go func() {
+		l.lock(2)()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("a dialog of user 1 blocked user 2")
+	}
+}
+
+// Запускается с -race: сообщения одного пользователя приходят в разных горутинах
+// и меняют одно и то же состояние мастера
+func TestWizardConcurrentMessages(t *testing.T) {
+	api := newFakeBotAPI(t)
+	b := api.bot(t)
+	svc := newTestService(t, service.Options{})
+	handler := textMessageHandler(svc, logger.New("error"))
+	const userID = 503
+
+	userStates.set(userID, &UserState{
+		CurrentDay:    time.Monday,
+		CurrentStep:   1,
+		ScheduleInput: make(map[time.Weekday][]domain.Lesson),
+	})
+	t.Cleanup(func() { userStates.delete(userID) })
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			text := fmt.Sprintf("Предмет %d | %02d:00 | %02d:45 | 305 | Иванов", i, 8+i, 8+i)
+			handler(context.Background(), b, messageUpdate(userID, text))
+		}(i)
+	}
+	wg.Wait()
+
+	state, ok := userStates.get(userID)
+	if !ok {
+		t.Fatal("wizard state disappeared")
+	}
+	if n := len(state.ScheduleInput[time.Monday]); n != 10 {
+		t.Errorf("wizard collected %d lessons, want 10", n)
+	}
+}
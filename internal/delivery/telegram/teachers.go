@@ -0,0 +1,252 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// teacherHandler обрабатывает команду /teacher <фамилия>
+func teacherHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		query := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/teacher"))
+
+		if query == "" {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.usage"))
+			return
+		}
+
+		teachers, err := svc.FindTeachers(ctx, query)
+		if err != nil {
+			log.Errorw("Failed to find teachers", "error", err, "query", query)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.error"))
+			return
+		}
+		if len(teachers) == 0 {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.not_found"))
+			return
+		}
+
+		var sb strings.Builder
+		for _, teacher := range teachers {
+			lessons, err := svc.TeacherWeek(ctx, userID, teacher)
+			if err != nil {
+				log.Errorw("Failed to get teacher lessons", "error", err, "teacherID", teacher.ID)
+			}
+
+			sb.WriteString(formatTeacher(lang, teacher))
+			if len(lessons) == 0 {
+				sb.WriteString(i18n.T(lang, "teacher.no_lessons"))
+				continue
+			}
+
+			sb.WriteString(i18n.T(lang, "teacher.week"))
+			for _, tl := range lessons {
+				sb.WriteString(fmt.Sprintf("    %s %s %s-%s %s\n",
+					i18n.ShortWeekday(lang, tl.Date.Weekday()), tl.Date.Format("02.01"),
+					tl.Lesson.StartTime.Format("15:04"), tl.Lesson.EndTime.Format("15:04"),
+					tl.Lesson.Name))
+			}
+			sb.WriteString("\n")
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   sb.String(),
+		}); err != nil {
+			log.Errorw("Failed to send teacher info", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// teachersHandler обрабатывает команду /teachers (весь справочник)
+func teachersHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		lang := userLang(ctx, svc, update.Message.From)
+
+		teachers, err := svc.ListTeachers(ctx)
+		if err != nil {
+			log.Errorw("Failed to list teachers", "error", err)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teachers.error"))
+			return
+		}
+
+		text := i18n.T(lang, "teachers.empty")
+		if len(teachers) > 0 {
+			var sb strings.Builder
+			sb.WriteString(i18n.T(lang, "teachers.title"))
+			for _, t := range teachers {
+				if t.Department != "" {
+					sb.WriteString(fmt.Sprintf("• %s (%s)\n", t.FullName, t.Department))
+				} else {
+					sb.WriteString(fmt.Sprintf("• %s\n", t.FullName))
+				}
+			}
+			text = sb.String()
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   text,
+		}); err != nil {
+			log.Errorw("Failed to send teachers list", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// addTeacherHandler обрабатывает команду администратора
+// /addteacher ФИО | Кафедра | Контакты | Часы приема
+func addTeacherHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		args := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/addteacher"))
+
+		parts := strings.Split(args, "|")
+		for len(parts) < 4 {
+			parts = append(parts, "")
+		}
+
+		teacher, err := svc.AddTeacher(ctx, userID, domain.Teacher{
+			FullName:    strings.TrimSpace(parts[0]),
+			Department:  strings.TrimSpace(parts[1]),
+			Contacts:    strings.TrimSpace(parts[2]),
+			OfficeHours: strings.TrimSpace(parts[3]),
+		})
+		if errors.Is(err, service.ErrForbidden) {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "admin.only"))
+			return
+		}
+		if err != nil {
+			log.Errorw("Failed to add teacher", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.add_usage"))
+			return
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "teacher.added") + formatTeacher(lang, teacher),
+		}); err != nil {
+			log.Errorw("Failed to confirm teacher", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// editTeacherHandler обрабатывает команду администратора
+// /editteacher <фамилия или ID> | ФИО | Кафедра | Контакты | Часы приема
+func editTeacherHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		args := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/editteacher"))
+
+		parts := strings.Split(args, "|")
+		query := strings.TrimSpace(parts[0])
+		if query == "" || len(parts) < 2 {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.edit_usage"))
+			return
+		}
+		for len(parts) < 5 {
+			parts = append(parts, "")
+		}
+
+		teacher, err := svc.UpdateTeacher(ctx, userID, query, domain.Teacher{
+			FullName:    parts[1],
+			Department:  parts[2],
+			Contacts:    parts[3],
+			OfficeHours: parts[4],
+		})
+		switch {
+		case errors.Is(err, service.ErrForbidden):
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "admin.only"))
+			return
+		case errors.Is(err, service.ErrTeacherNotFound):
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.delete_missing"))
+			return
+		case errors.Is(err, service.ErrTeacherAmbiguous):
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.ambiguous"))
+			return
+		case err != nil:
+			log.Errorw("Failed to update teacher", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.edit_usage"))
+			return
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "teacher.updated") + formatTeacher(lang, teacher),
+		}); err != nil {
+			log.Errorw("Failed to confirm teacher update", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// deleteTeacherHandler обрабатывает команду администратора /delteacher <фамилия или ID>
+func deleteTeacherHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		chatID := update.Message.Chat.ID
+		userID := update.Message.From.ID
+		lang := userLang(ctx, svc, update.Message.From)
+		query := strings.TrimSpace(strings.TrimPrefix(update.Message.Text, "/delteacher"))
+
+		teacher, err := svc.DeleteTeacher(ctx, userID, query)
+		switch {
+		case errors.Is(err, service.ErrForbidden):
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "admin.only"))
+			return
+		case errors.Is(err, service.ErrTeacherNotFound):
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.delete_missing"))
+			return
+		case errors.Is(err, service.ErrTeacherAmbiguous):
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.ambiguous"))
+			return
+		case err != nil:
+			log.Errorw("Failed to delete teacher", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "teacher.delete_error"))
+			return
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   i18n.T(lang, "teacher.deleted", teacher.FullName),
+		}); err != nil {
+			log.Errorw("Failed to confirm teacher deletion", "error", err, "chatID", chatID)
+		}
+	}
+}
+
+// formatTeacher форматирует карточку преподавателя
+func formatTeacher(lang i18n.Lang, t domain.Teacher) string {
+	var sb strings.Builder
+	sb.WriteString(fmt.Sprintf("👨‍🏫 %s\n", t.FullName))
+	if t.Department != "" {
+		sb.WriteString(fmt.Sprintf("🏛 %s\n", t.Department))
+	}
+	if t.Contacts != "" {
+		sb.WriteString(fmt.Sprintf("📞 %s\n", t.Contacts))
+	}
+	if t.OfficeHours != "" {
+		sb.WriteString(i18n.T(lang, "teacher.office_hours", t.OfficeHours))
+	}
+	sb.WriteString(fmt.Sprintf("ID: %s\n", t.ID))
+	return sb.String()
+}
@@ -0,0 +1,44 @@
+package telegram
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+func TestEditTeacherHandler(t *testing.T) {
+	ctx := context.Background()
+	api := newFakeBotAPI(t)
+	b := api.bot(t)
+	svc := newTestService(t, service.Options{Admins: []int64{1}})
+	handler := editTeacherHandler(svc, logger.New("error"))
+
+	teacher, err := svc.AddTeacher(ctx, 1, domain.Teacher{FullName: "Иванов И.И.", Department: "Физика"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	handler(ctx, b, messageUpdate(1, "/editteacher Иванов"))
+	handler(ctx, b, messageUpdate(1, "/editteacher Иванов | | Математика | | Ср 14:00"))
+
+	sent := api.sent("sendMessage")
+	if len(sent) != 2 {
+		t.Fatalf("sent %d messages, want 2", len(sent))
+	}
+	if !strings.Contains(sent[0].params["text"], i18n.T(i18n.Russian, "teacher.edit_usage")) {
+		t.Errorf("reply without fields = %q, want usage", sent[0].params["text"])
+	}
+	if text := sent[1].params["text"]; !strings.Contains(text, "Математика") || !strings.Contains(text, "Ср 14:00") {
+		t.Errorf("confirmation = %q", text)
+	}
+
+	found, _ := svc.FindTeachers(ctx, teacher.ID)
+	if len(found) != 1 || found[0].FullName != "Иванов И.И." || found[0].Department != "Математика" || found[0].OfficeHours != "Ср 14:00" {
+		t.Errorf("stored = %+v", found)
+	}
+}
@@ -0,0 +1,117 @@
+package telegram
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+const (
+	// maxVoiceDuration ограничивает длину голосового сообщения для распознавания
+	maxVoiceDuration = 5 * time.Minute
+	// maxVoiceSize - ограничение Whisper на размер файла
+	maxVoiceSize = 25 << 20
+)
+
+// isVoiceMessage проверяет, что апдейт содержит голосовое сообщение
+func isVoiceMessage(update *models.Update) bool {
+	return update.Message != nil && update.Message.Voice != nil
+}
+
+// voiceHandler распознает голосовое сообщение и передает текст
+// в мастер ввода расписания или AI-помощнику
+func voiceHandler(svc *service.Service, log logger.Logger) bot.HandlerFunc {
+	return func(ctx context.Context, b *bot.Bot, update *models.Update) {
+		log := logger.FromContext(ctx, log)
+		userID := update.Message.From.ID
+		chatID := update.Message.Chat.ID
+		voice := update.Message.Voice
+		lang := userLang(ctx, svc, update.Message.From)
+
+		if time.Duration(voice.Duration)*time.Second > maxVoiceDuration || voice.FileSize > maxVoiceSize {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "voice.too_long"))
+			return
+		}
+
+		audio, err := downloadFile(ctx, b, voice.FileID)
+		if err != nil {
+			log.Errorw("Failed to download voice message", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "voice.download_failed"))
+			return
+		}
+		defer audio.Close()
+
+		// Язык речи Whisper определяет сам: язык интерфейса для многих
+		// пользователей выбран по умолчанию и не совпадает с языком сообщения
+		transcript, err := svc.TranscribeVoice(ctx, userID, audio, "voice.ogg", "")
+		var limitErr *service.LimitError
+		if errors.As(err, &limitErr) {
+			log.Infow("Voice transcription limited", "userID", userID, "reason", limitErr.Reason)
+			sendErrorMessage(b, ctx, chatID, limitMessage(lang, limitErr))
+			return
+		}
+		if err != nil {
+			log.Errorw("Failed to transcribe voice message", "error", err, "userID", userID)
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "voice.transcribe_failed"))
+			return
+		}
+
+		text := transcript.Text
+		if text == "" {
+			sendErrorMessage(b, ctx, chatID, i18n.T(lang, "voice.empty"))
+			return
+		}
+
+		if err := sendMessage(ctx, b, &bot.SendMessageParams{
+			ChatID: chatID,
+			Text:   fmt.Sprintf("🎤 %s", text),
+		}); err != nil {
+			log.Errorw("Failed to send transcription", "error", err, "chatID", chatID)
+		}
+
+		unlock := dialogLocks.lock(userID)
+		handled := handleEditInput(ctx, b, svc, log, lang, userID, chatID, text) ||
+			handleScheduleInput(ctx, b, svc, log, lang, userID, chatID, text)
+		unlock()
+		if handled {
+			return
+		}
+
+		answer, err := svc.AnswerTranscript(ctx, transcript)
+		sendAnswer(ctx, b, log, lang, userID, chatID, text, answer, err)
+	}
+}
+
+// downloadFile скачивает файл с серверов Telegram
+func downloadFile(ctx context.Context, b *bot.Bot, fileID string) (io.ReadCloser, error) {
+	file, err := b.GetFile(ctx, &bot.GetFileParams{FileID: fileID})
+	if err != nil {
+		return nil, fmt.Errorf("get file: %w", redactURL(err))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, b.FileDownloadLink(file), nil)
+	if err != nil {
+		return nil, fmt.Errorf("download file: %w", redactURL(err))
+	}
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("download file: %w", redactURL(err))
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		resp.Body.Close()
+		return nil, fmt.Errorf("download file: unexpected status %s", resp.Status)
+	}
+
+	return resp.Body, nil
+}
@@ -0,0 +1,60 @@
+package telegram
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-telegram/bot"
+)
+
+func TestDownloadFileErrorsHideToken(t *testing.T) {
+	const token = "1:secret-token"
+
+	// getFile отвечает, а скачивание файла обрывает соединение
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/file/") {
+			conn, _, err := w.(http.Hijacker).Hijack()
+			if err == nil {
+				conn.Close()
+			}
+			return
+		}
+		_, _ = w.Write([]byte(`{"ok":true,"result":{"file_id":"f","file_unique_id":"u","file_path":"voice/file.oga"}}`))
+	}))
+	t.Cleanup(server.Close)
+
+	b, err := bot.New(token, bot.WithServerURL(server.URL), bot.WithSkipGetMe())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := downloadFile(context.Background(), b, "f"); err == nil || strings.Contains(err.Error(), token) {
+		t.Errorf("download error = %v, want an error without the token", err)
+	}
+
+	// Bot API недоступен: ошибка getFile тоже содержит URL с токеном
+	server.Close()
+	if _, err := downloadFile(context.Background(), b, "f"); err == nil || strings.Contains(err.Error(), token) {
+		t.Errorf("getFile error = %v, want an error without the token", err)
+	}
+}
+
+func TestHTTPClientHidesToken(t *testing.T) {
+	const token = "1:secret-token"
+	server := httptest.NewServer(http.NotFoundHandler())
+	server.Close()
+
+	b, err := bot.New(token, bot.WithServerURL(server.URL), bot.WithSkipGetMe(),
+		bot.WithHTTPClient(time.Second, NewHTTPClient(time.Second)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	_, err = b.SendMessage(context.Background(), &bot.SendMessageParams{ChatID: 1, Text: "hi"})
+	if err == nil || strings.Contains(err.Error(), token) {
+		t.Errorf("sendMessage error = %v, want an error without the token", err)
+	}
+}
@@ -0,0 +1,71 @@
+package telegram
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/go-telegram/bot"
+	"github.com/go-telegram/bot/models"
+	"github.com/polyk005/tg_bot/internal/i18n"
+	"github.com/polyk005/tg_bot/internal/render"
+	"github.com/polyk005/tg_bot/internal/service"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// sendWeekImage отправляет расписание на неделю картинкой-сеткой.
+// Как и в текстовом виде, суббота показывается, только если в ней есть пары.
+func sendWeekImage(ctx context.Context, b *bot.Bot, svc *service.Service, log logger.Logger, lang i18n.Lang, userID, chatID int64, weekOffset int) {
+	now := svc.Now(ctx, userID)
+	monday := now.AddDate(0, 0, -(int(now.Weekday())+6)%7+7*weekOffset)
+
+	table := render.Timetable{
+		Title: i18n.T(lang, "week.image_title", monday.Format("02.01"), monday.AddDate(0, 0, 6).Format("02.01")),
+	}
+
+	bells, _, err := svc.UserBellSchedule(ctx, userID)
+	if err != nil {
+		log.Errorw("Failed to get bell schedule", "error", err, "userID", userID)
+	}
+	table.Bells = bells
+
+	for offset := 0; offset < 6; offset++ {
+		date := monday.AddDate(0, 0, offset)
+		day := date.Weekday()
+
+		lessons, err := svc.LessonsOn(ctx, userID, date)
+		if err != nil {
+			log.Errorw("Failed to get schedule", "error", err, "userID", userID, "day", day)
+			continue
+		}
+
+		if day == time.Saturday && len(lessons) == 0 {
+			continue
+		}
+
+		column := render.TimetableDay{
+			Title:   fmt.Sprintf("%s %s", i18n.ShortWeekday(lang, day), date.Format("02.01")),
+			Lessons: lessons,
+		}
+		if holiday, ok := svc.Holiday(date); ok {
+			column.Note = holiday
+		}
+		table.Days = append(table.Days, column)
+	}
+
+	image, err := render.TimetablePNG(table)
+	if err != nil {
+		log.Errorw("Failed to render week schedule", "error", err, "userID", userID)
+		sendErrorMessage(b, ctx, chatID, i18n.T(lang, "week.image_error"))
+		return
+	}
+
+	if _, err := b.SendPhoto(ctx, &bot.SendPhotoParams{
+		ChatID:  chatID,
+		Photo:   &models.InputFileUpload{Filename: "week.png", Data: bytes.NewReader(image)},
+		Caption: table.Title,
+	}); err != nil {
+		log.Errorw("Failed to send week image", "error", err, "chatID", chatID)
+	}
+}
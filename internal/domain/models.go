@@ -2,16 +2,124 @@ package domain
 
 import "time"
 
+// Role определяет уровень доступа пользователя
+type Role string
+
+const (
+	RoleUser  Role = "user"
+	RoleAdmin Role = "admin"
+)
+
 type User struct {
-	ID        int64
-	Username  string
-	CreatedAt time.Time
+	ID           int64
+	Username     string
+	FirstName    string
+	LastName     string
+	LanguageCode string // language_code из Telegram
+	CreatedAt    time.Time
+	LastSeen     time.Time
+	Language     string // выбранный через /language код языка, пустой - по language_code из Telegram
+	Blocked      bool   // пользователь заблокировал бота, рассылки ему не отправляются
 }
 
 type Lesson struct {
+	ID        string
 	Name      string
 	StartTime time.Time
 	EndTime   time.Time
 	Location  string
 	Teacher   string
+	TeacherID string // ID из справочника преподавателей, если удалось связать
+	Slot      int    // Номер пары по звонкам, 0 - время задано вручную
+	Week      int    // 0 - каждую неделю, 1 - по числителям, 2 - по знаменателям
+}
+
+// StartOn возвращает время начала пары в указанный день (в часовом поясе date).
+// StartTime хранит только время суток, дата в нем не используется.
+func (l Lesson) StartOn(date time.Time) time.Time {
+	return clockOn(l.StartTime, date)
+}
+
+// EndOn возвращает время окончания пары в указанный день
+func (l Lesson) EndOn(date time.Time) time.Time {
+	return clockOn(l.EndTime, date)
+}
+
+// clockOn переносит время суток clock на дату date
+func clockOn(clock, date time.Time) time.Time {
+	return time.Date(date.Year(), date.Month(), date.Day(), clock.Hour(), clock.Minute(), 0, 0, date.Location())
+}
+
+// Teacher - запись справочника преподавателей
+type Teacher struct {
+	ID          string
+	FullName    string
+	Department  string
+	Contacts    string
+	OfficeHours string
+}
+
+// Building - корпус кампуса
+type Building struct {
+	Code      string
+	Name      string
+	Address   string
+	Latitude  float64
+	Longitude float64
+}
+
+// Room - аудитория с привязкой к корпусу
+type Room struct {
+	Code     string
+	Building Building
+	Floor    int
+}
+
+// UserSettings хранит персональные настройки пользователя
+type UserSettings struct {
+	UserID       int64
+	BellSchedule string
+	TimeZone     string // IANA, например Europe/Moscow
+}
+
+// BellSlot - одна пара в расписании звонков
+type BellSlot struct {
+	Number    int
+	StartTime time.Time
+	EndTime   time.Time
+}
+
+// BellSchedule - расписание звонков учебного заведения или группы
+type BellSchedule struct {
+	Name  string
+	Slots []BellSlot
+}
+
+// Slot возвращает пару звонков по номеру
+func (bs BellSchedule) Slot(number int) (BellSlot, bool) {
+	for _, slot := range bs.Slots {
+		if slot.Number == number {
+			return slot, true
+		}
+	}
+	return BellSlot{}, false
+}
+
+// SlotAt возвращает номер пары, начинающейся в указанное время, или 0
+func (bs BellSchedule) SlotAt(start time.Time) int {
+	for _, slot := range bs.Slots {
+		if slot.StartTime.Hour() == start.Hour() && slot.StartTime.Minute() == start.Minute() {
+			return slot.Number
+		}
+	}
+	return 0
+}
+
+// AIUsage хранит счетчики обращений пользователя к AI-помощнику
+type AIUsage struct {
+	UserID     int64
+	Day        string // 2006-01-02
+	DayCount   int
+	Month      string // 2006-01
+	MonthCount int
 }
@@ -5,4 +5,38 @@ import "context"
 type Repository interface {
 	CreateUser(ctx context.Context, userID int64) error
 	UserExists(ctx context.Context, userID int64) (bool, error)
+	GetUser(ctx context.Context, userID int64) (User, error)
+	SaveUser(ctx context.Context, user User) error
+	// UpsertUserProfile создает пользователя или обновляет данные из Telegram
+	// (username, имя, language_code, LastSeen), не трогая остальные поля
+	UpsertUserProfile(ctx context.Context, profile User) error
+	// ListUsers возвращает всех пользователей
+	ListUsers(ctx context.Context) ([]User, error)
+	// SetUserBlocked отмечает, что пользователь заблокировал бота (или снова доступен)
+	SetUserBlocked(ctx context.Context, userID int64, blocked bool) error
+	// DeleteUser удаляет пользователя и все связанные с ним записи (настройки, счетчики AI)
+	DeleteUser(ctx context.Context, userID int64) error
+
+	UsageRepository
+	SettingsRepository
+	TeacherRepository
+}
+
+// TeacherRepository хранит справочник преподавателей
+type TeacherRepository interface {
+	SaveTeacher(ctx context.Context, teacher Teacher) error
+	DeleteTeacher(ctx context.Context, id string) error
+	ListTeachers(ctx context.Context) ([]Teacher, error)
+}
+
+// SettingsRepository хранит персональные настройки пользователей
+type SettingsRepository interface {
+	GetSettings(ctx context.Context, userID int64) (UserSettings, error)
+	SaveSettings(ctx context.Context, settings UserSettings) error
+}
+
+// UsageRepository хранит счетчики использования AI
+type UsageRepository interface {
+	GetAIUsage(ctx context.Context, userID int64) (AIUsage, error)
+	SaveAIUsage(ctx context.Context, usage AIUsage) error
 }
@@ -0,0 +1,154 @@
+package i18n
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Lang - язык интерфейса бота
+type Lang string
+
+const (
+	Russian Lang = "ru"
+	English Lang = "en"
+
+	// Default используется, если перевода нет или язык неизвестен
+	Default = Russian
+)
+
+var catalogs = map[Lang]map[string]string{
+	Russian: ru,
+	English: en,
+}
+
+// Supported возвращает все языки, для которых есть каталог сообщений
+func Supported() []Lang {
+	return []Lang{Russian, English}
+}
+
+// Name возвращает название языка на нем самом
+func (l Lang) Name() string {
+	switch l {
+	case English:
+		return "English"
+	default:
+		return "Русский"
+	}
+}
+
+// Parse разбирает код или название языка ("en", "English", "русский")
+func Parse(text string) (Lang, bool) {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "ru", "rus", "russian", "русский", "рус":
+		return Russian, true
+	case "en", "eng", "english", "английский", "англ":
+		return English, true
+	}
+	return "", false
+}
+
+// FromCode выбирает язык по language_code из Telegram (например, "en-US").
+// Для локалей без своего каталога используется Default: пользователь
+// всегда может сменить язык командой /language.
+func FromCode(code string) Lang {
+	switch Lang(strings.ToLower(strings.SplitN(code, "-", 2)[0])) {
+	case Russian:
+		return Russian
+	case English:
+		return English
+	default:
+		return Default
+	}
+}
+
+// T возвращает сообщение по ключу, подставляя аргументы через fmt.Sprintf.
+// Если перевода нет, используется русский каталог, а затем сам ключ.
+func T(lang Lang, key string, args ...interface{}) string {
+	msg, ok := catalogs[lang][key]
+	if !ok {
+		msg, ok = catalogs[Default][key]
+	}
+	if !ok {
+		msg = key
+	}
+
+	if len(args) == 0 {
+		return msg
+	}
+	return fmt.Sprintf(msg, args...)
+}
+
+var weekdays = map[Lang][7]string{
+	Russian: {"Воскресенье", "Понедельник", "Вторник", "Среда", "Четверг", "Пятница", "Суббота"},
+	English: {"Sunday", "Monday", "Tuesday", "Wednesday", "Thursday", "Friday", "Saturday"},
+}
+
+var shortWeekdays = map[Lang][7]string{
+	Russian: {"Вс", "Пн", "Вт", "Ср", "Чт", "Пт", "Сб"},
+	English: {"Su", "Mo", "Tu", "We", "Th", "Fr", "Sa"},
+}
+
+var months = map[Lang][13]string{
+	Russian: {"", "Январь", "Февраль", "Март", "Апрель", "Май", "Июнь",
+		"Июль", "Август", "Сентябрь", "Октябрь", "Ноябрь", "Декабрь"},
+	English: {"", "January", "February", "March", "April", "May", "June",
+		"July", "August", "September", "October", "November", "December"},
+}
+
+// Weekday возвращает название дня недели
+func Weekday(lang Lang, day time.Weekday) string {
+	names, ok := weekdays[lang]
+	if !ok {
+		names = weekdays[Default]
+	}
+	return names[day]
+}
+
+// ShortWeekday возвращает сокращенное название дня недели
+func ShortWeekday(lang Lang, day time.Weekday) string {
+	names, ok := shortWeekdays[lang]
+	if !ok {
+		names = shortWeekdays[Default]
+	}
+	return names[day]
+}
+
+// Month возвращает название месяца
+func Month(lang Lang, month time.Month) string {
+	names, ok := months[lang]
+	if !ok {
+		names = months[Default]
+	}
+	return names[month]
+}
+
+// ParseWeekday распознает день недели на любом поддерживаемом языке,
+// полностью или сокращенно ("пятница", "пт", "Friday", "fri")
+func ParseWeekday(text string) (time.Weekday, bool) {
+	text = strings.ToLower(strings.TrimSpace(text))
+	if text == "" {
+		return time.Sunday, false
+	}
+
+	for _, lang := range Supported() {
+		for day := time.Sunday; day <= time.Saturday; day++ {
+			full := strings.ToLower(weekdays[lang][day])
+			short := strings.ToLower(shortWeekdays[lang][day])
+			if text == full || text == short || (len([]rune(text)) >= 3 && strings.HasPrefix(full, text)) {
+				return day, true
+			}
+		}
+	}
+
+	return time.Sunday, false
+}
+
+// IsYes проверяет утвердительный ответ на любом поддерживаемом языке
+func IsYes(text string) bool {
+	switch strings.ToLower(strings.TrimSpace(text)) {
+	case "да", "д", "ага", "yes", "y", "yeah", "yep":
+		return true
+	}
+	return false
+}
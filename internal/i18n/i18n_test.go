@@ -0,0 +1,42 @@
+package i18n
+
+import "testing"
+
+func TestFromCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want Lang
+	}{
+		{"ru", Russian},
+		{"RU", Russian},
+		{"en", English},
+		{"en-US", English},
+		{"uk", Default},
+		{"de", Default},
+		{"pt-BR", Default},
+		{"", Default},
+	}
+
+	for _, tt := range tests {
+		if got := FromCode(tt.code); got != tt.want {
+			t.Errorf("FromCode(%q) = %q, want %q", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestCatalogsHaveSameKeys(t *testing.T) {
+	for key := range catalogs[Default] {
+		for _, lang := range Supported() {
+			if _, ok := catalogs[lang][key]; !ok {
+				t.Errorf("%s catalog has no %q", lang, key)
+			}
+		}
+	}
+	for _, lang := range Supported() {
+		for key := range catalogs[lang] {
+			if _, ok := catalogs[Default][key]; !ok {
+				t.Errorf("%s catalog has extra key %q", lang, key)
+			}
+		}
+	}
+}
@@ -0,0 +1,258 @@
+package i18n
+
+// en - английский каталог сообщений
+var en = map[string]string{
+	// Общие
+	"start.error": "Could not get started. Please try again later.",
+	"start.welcome": `Hi! I'm a bot that keeps track of your class schedule.
+This is study week %d (%s).
+
+Main commands:
+/setschedule - set up your schedule
+/edit - change or delete classes
+/bells - bell schedule
+/now - the current and the next class
+/today - today's schedule
+/tomorrow - tomorrow's schedule
+/week - schedule for the week
+/day [date or weekday] - schedule for any day
+/ask [question] - ask the AI assistant
+/usage - AI assistant limits
+/language - interface language`,
+	"help.text": `ℹ️ Available commands:
+
+/start - Get started
+/help - This help
+/setschedule - Set up your schedule
+/edit - Change, delete or reorder classes
+/bells - Bell schedule
+/timezone [zone] - Time zone
+/language - Interface language
+/profile - Your profile
+/mydata - Export my data
+/deleteme - Delete my data
+/teacher [surname] - Teacher info and your classes with them
+/teachers - Teacher directory
+/where [room] - Where a room is
+/now - Current and next class
+/today - Today's schedule
+/tomorrow - Tomorrow's schedule
+/week - Schedule for the week (/week next - for the next one, /week image - as a picture)
+/day [date or weekday] - Schedule for a date, e.g. /day 21.10 or /day friday
+/ask [question] - Ask the AI assistant
+/usage - AI assistant usage
+
+🎤 Voice message - a question for the AI assistant or a class while filling in the schedule`,
+	"parity.odd":      "odd week",
+	"parity.even":     "even week",
+	"admin.only":      "This command is available to administrators only.",
+	"error.internal":  "Something went wrong. Please try again later.",
+	"error.throttled": "Too many requests, please wait a moment.",
+	"answer.retry":    "I didn't understand the answer, please try again.",
+
+	// Ввод расписания
+	"wizard.intro": "📝 Enter your schedule. First choose a weekday (for example, 'Monday')\n" +
+		"Or send /cancel to cancel",
+	"wizard.cancelled": "❌ Schedule input cancelled",
+	"wizard.bad_day":   "Unknown weekday. Please choose a day from the list:",
+	"wizard.lessons_prompt": "📅 Enter the classes for %s, one per message.\n\n" +
+		"Free text works:\n" +
+		"calculus at 9 in 101 with Ivanov\n" +
+		"Physics 10:40-12:10 room 305\n\n" +
+		"By class number (see /bells):\n" +
+		"2 | Physics | 305 | Petrov\n\n" +
+		"Or in the format:\n" +
+		"Mathematics | 09:00 | 10:30 | Room 101 | Ivanov I.I.\n\n" +
+		"When you're done, send /done",
+	"wizard.day_done":     "%s saved. Do you want to add another day? (yes/no)",
+	"wizard.unknown_slot": "Your bell schedule has no class with that number. See the bells: /bells",
+	"wizard.parse_failed": "Could not parse the class. Write, for example:\n" +
+		"Physics 10:40-12:10 room 305 with Petrov\n" +
+		"or Name | Start | End | Room | Teacher",
+	"wizard.next_day":          "Choose the next weekday:",
+	"wizard.day_invalid":       "❌ %s was not saved:\n",
+	"wizard.day_failed":        "❌ %s was not saved due to an internal error\n",
+	"wizard.day_warnings":      "⚠️ %s:\n",
+	"wizard.saved":             "✅ Schedule saved!",
+	"wizard.saved_with_issues": "Schedule saved with remarks:\n\n",
+	"wizard.not_saved":         "Not every day was saved:\n\n",
+	"wizard.reenter_day":       "\nChoose a day to enter it again, or /cancel to quit.",
+	"wizard.lesson_rejected":   "❌ Class not added:\n%s\nPlease enter it again.",
+	"wizard.lesson_added":      "✅ Class added: %s %s-%s. Enter the next one or /done to finish",
+	"wizard.lesson_warnings":   "\n\n⚠️ Please note:\n",
+
+	// Вопросы о недостающих полях пары
+	"field.name.ask":     "What is the subject called?",
+	"field.time.ask":     "What time is the class? For example: 10:40-12:10 or 9:00",
+	"field.location.ask": "Which room? Send - if unknown",
+	"field.teacher.ask":  "Who is the teacher? Send - if unknown",
+
+	// Проблемы расписания
+	"issue.inverted_time": "• %s: end time (%s) is not after start time (%s)\n",
+	"issue.empty_name":    "• The class has no name\n",
+	"issue.overlap":       "• %s overlaps with %s (%s-%s)\n",
+	"issue.too_long":      "• %s lasts longer than %d h\n",
+	"issue.long_gap":      "• Long break between %s and %s (%s-%s)\n",
+
+	// Просмотр расписания
+	"schedule.error":         "Could not load the schedule. Please try again later.",
+	"schedule.menu":          "Choose a period to view:",
+	"schedule.today":         "Today",
+	"schedule.tomorrow":      "Tomorrow",
+	"schedule.week":          "Whole week",
+	"schedule.next_week":     "Next week",
+	"schedule.pick_date":     "📆 Pick a date",
+	"day.holiday":            "%s, %s - day off: %s 🎉",
+	"day.empty":              "No classes on %s, %s 🎉",
+	"day.title":              "📅 Schedule for %s, %s (%s):\n\n",
+	"day.lesson_slot":        "%d. %s (class %d)\n",
+	"day.calendar":           "📆 Pick a date:",
+	"day.bad_date":           "I didn't understand the date. Examples: /day 2026-10-21, /day 21.10, /day friday",
+	"week.usage":             "Use /week, /week next or /week image",
+	"week.image_title":       "Schedule for the week %s - %s",
+	"week.image_error":       "Could not draw the schedule. Try the text version: /week",
+	"week.title":             "📅 Your schedule for the week:\n\n",
+	"week.title_next":        "📅 Your schedule for next week:\n\n",
+	"week.no_lessons":        "No classes",
+	"week.next_button":       "Next week »",
+	"week.current_button":    "« This week",
+	"transfer.warning":       "⚠️ After %s you have to walk from «%s» to «%s» with only %s of break\n",
+	"duration.minutes":       "%d min",
+	"duration.hours":         "%d h",
+	"duration.hours_minutes": "%d h %d min",
+
+	// /now и /timezone
+	"now.current":      "🟢 Now: %s\n",
+	"now.current_ends": "   ends in %s (at %s)\n",
+	"now.no_current":   "⚪️ No class right now\n",
+	"now.no_next":      "\nNo classes in the next two weeks 🎉",
+	"now.next":         "\n⏭ Next: %s\n",
+	"now.next_today":   "   at %s, in %s\n",
+	"now.next_later":   "   %s, %s at %s\n",
+	"now.break":        "\n☕️ Break: %s\n",
+	"timezone.current": "🌍 Your time zone: %s\nTo change it, send, for example:\n/timezone Europe/London",
+	"timezone.unknown": "Unknown time zone. Examples: Europe/Moscow, Asia/Yekaterinburg",
+	"timezone.error":   "Could not save the time zone. Please try again later.",
+	"timezone.saved":   "✅ Time zone: %s",
+
+	// /language
+	"language.choose":  "🌐 Interface language: %s\nChoose a language:",
+	"language.unknown": "Unknown language. Available: ru, en",
+	"language.error":   "Could not save the language. Please try again later.",
+	"language.saved":   "✅ Interface language: %s",
+
+	// Редактирование
+	"edit.pick_day":       "✏️ Choose the day whose classes you want to change:",
+	"edit.pick_lesson":    "✏️ %s: choose a class",
+	"edit.no_lessons":     "No classes on %s",
+	"edit.not_found":      "Class not found. It may have been deleted already.",
+	"edit.rejected":       "❌ Change not saved:\n",
+	"edit.updated":        "✅ Class updated:\n\n",
+	"edit.warnings":       "\n⚠️ Please note:\n",
+	"edit.field.name":     "Name",
+	"edit.field.time":     "Time",
+	"edit.field.location": "Room",
+	"edit.field.teacher":  "Teacher",
+	"edit.up":             "⬆️ Up",
+	"edit.down":           "⬇️ Down",
+	"edit.delete":         "🗑 Delete",
+	"edit.back":           "« Back",
+
+	// Звонки
+	"bells.not_configured": "No bell schedules are configured.",
+	"bells.error":          "Could not load the bell schedule. Please try again later.",
+	"bells.not_selected":   "🔔 No bell schedule selected.",
+	"bells.selected":       "✅ Bell schedule selected.\n\n",
+	"bells.title":          "🔔 Bells (%s):\n\n",
+	"bells.slot":           "Class %d: %s - %s\n",
+
+	// AI-помощник
+	"ask.empty":             "Please write your question after the /ask command",
+	"ask.error":             "Something went wrong while processing your question.",
+	"limit.daily":           "The daily AI assistant limit is used up. Try again tomorrow.",
+	"limit.monthly":         "The monthly AI assistant limit is used up.",
+	"limit.rate":            "Too many questions in a row. Try again in %d s.",
+	"usage.error":           "Could not load the statistics. Please try again later.",
+	"usage.text":            "🤖 AI assistant usage:\n\nToday: %s\nThis month: %s\nAt most: %s",
+	"usage.unlimited":       "unlimited",
+	"usage.quota":           "%d of %d",
+	"usage.quota_unlimited": "%d (unlimited)",
+	"usage.rate":            "%d requests per minute",
+
+	// Голосовые сообщения
+	"voice.too_long":          "The voice message is too long. Please keep it shorter.",
+	"voice.download_failed":   "Could not fetch the voice message.",
+	"voice.transcribe_failed": "Could not recognize the voice message.",
+	"voice.empty":             "Could not make out any words. Please try again.",
+
+	// Преподаватели
+	"teacher.usage":          "Specify a surname: /teacher Ivanov",
+	"teacher.error":          "Could not search for the teacher. Please try again later.",
+	"teacher.not_found":      "Teacher not found in the directory.",
+	"teacher.no_lessons":     "\nNo classes with this teacher this week\n\n",
+	"teacher.week":           "\n📅 Classes this week:\n",
+	"teacher.office_hours":   "🕒 Office hours: %s\n",
+	"teachers.error":         "Could not load the directory. Please try again later.",
+	"teachers.empty":         "The teacher directory is empty.",
+	"teachers.title":         "👨‍🏫 Teachers:\n\n",
+	"teacher.add_usage":      "Format: /addteacher Full name | Department | Contacts | Office hours",
+	"teacher.added":          "✅ Teacher added:\n\n",
+	"teacher.edit_usage":     "Format: /editteacher surname or ID | Full name | Department | Contacts | Office hours\nEmpty fields stay unchanged, - clears a field.",
+	"teacher.updated":        "✏️ Teacher updated:\n\n",
+	"teacher.delete_missing": "Teacher not found.",
+	"teacher.ambiguous":      "Several teachers match, specify the ID from /teacher.",
+	"teacher.delete_error":   "Could not delete the teacher. Please try again later.",
+	"teacher.deleted":        "🗑 %s removed from the directory",
+
+	// Аудитории
+	"where.usage":     "Specify a room: /where 305",
+	"where.not_found": "Room not found in the directory.",
+	"where.title":     "Room %s, floor %d",
+
+	// Уровень логирования
+	"loglevel.current": "Log level: %s\nChange it: /loglevel debug|info|warn|error",
+	"loglevel.invalid": "Unknown level. Allowed: debug, info, warn, error.",
+	"loglevel.set":     "✅ Log level: %s",
+
+	// Профиль
+	"profile.title":      "👤 Profile",
+	"profile.id":         "ID: %s",
+	"profile.username":   "Username: %s",
+	"profile.name":       "Name: %s",
+	"profile.tg_lang":    "Telegram language: %s",
+	"profile.lang":       "Bot language: %s",
+	"profile.role":       "Role: %s",
+	"profile.timezone":   "Time zone: %s",
+	"profile.bells":      "Bell schedule: %s",
+	"profile.created":    "Registered: %s",
+	"profile.last_seen":  "Last active: %s",
+	"profile.role_admin": "administrator",
+	"profile.role_user":  "user",
+	"profile.empty":      "—",
+	"profile.error":      "Could not load your profile. Please try again later.",
+
+	// Мои данные
+	"mydata.caption":     "📦 All the data the bot keeps about you",
+	"mydata.error":       "Could not export your data. Please try again later.",
+	"deleteme.confirm":   "⚠️ Delete all your data: profile, settings, schedule and AI counters? This cannot be undone.",
+	"deleteme.yes":       "🗑 Delete",
+	"deleteme.no":        "Cancel",
+	"deleteme.done":      "✅ Your data has been deleted. If you keep using the bot, it will start storing data again.",
+	"deleteme.cancelled": "Deletion cancelled.",
+	"deleteme.error":     "Could not delete your data. Please try again later.",
+
+	// Рассылка
+	"broadcast.usage":          "Specify the text: /broadcast Classes on 21.10 are moved to 12:10\nFor one group only: /broadcast #main Classes on 21.10 are moved to 12:10",
+	"broadcast.unknown_group":  "Group \"%s\" not found. Available groups: %s",
+	"broadcast.preview_group":  "📣 Broadcast preview for group %s, recipients: %d",
+	"broadcast.too_long":       "The broadcast text is longer than %d characters.",
+	"broadcast.preview":        "📣 Broadcast preview, recipients: %d",
+	"broadcast.send":           "📤 Send",
+	"broadcast.cancel":         "Cancel",
+	"broadcast.cancelled":      "Broadcast cancelled.",
+	"broadcast.expired":        "This broadcast has already been sent or cancelled.",
+	"broadcast.sending":        "⏳ Broadcast started, recipients: %d",
+	"broadcast.report":         "📣 Broadcast finished\nDelivered: %d\nBlocked the bot: %d\nNot delivered: %d",
+	"broadcast.report_stopped": "\nNot sent because the bot stopped: %d. Repeat the broadcast after restart.",
+	"broadcast.error":          "Could not start the broadcast. Please try again later.",
+}
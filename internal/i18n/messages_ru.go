@@ -0,0 +1,258 @@
+package i18n
+
+// ru - русский каталог сообщений (язык по умолчанию)
+var ru = map[string]string{
+	// Общие
+	"start.error": "Не удалось начать работу. Попробуйте позже.",
+	"start.welcome": `Привет! Я бот для управления расписанием.
+Сейчас %d-я учебная неделя (%s).
+
+Основные команды:
+/setschedule - установить расписание
+/edit - изменить или удалить пары
+/bells - расписание звонков
+/now - какая пара сейчас и какая следующая
+/today - расписание на сегодня
+/tomorrow - расписание на завтра
+/week - расписание на неделю
+/day [дата или день] - расписание на любой день
+/ask [вопрос] - задать вопрос AI-помощнику
+/usage - лимиты AI-помощника
+/language - язык интерфейса`,
+	"help.text": `ℹ️ Доступные команды:
+
+/start - Начало работы
+/help - Эта справка
+/setschedule - Установить расписание
+/edit - Изменить, удалить или переставить пары
+/bells - Расписание звонков
+/timezone [пояс] - Часовой пояс
+/language - Язык интерфейса
+/profile - Ваш профиль
+/mydata - Выгрузить мои данные
+/deleteme - Удалить мои данные
+/teacher [фамилия] - Информация о преподавателе и пары с ним
+/teachers - Справочник преподавателей
+/where [аудитория] - Где находится аудитория
+/now - Текущая и следующая пара
+/today - Расписание на сегодня
+/tomorrow - Расписание на завтра
+/week - Расписание на неделю (/week next - на следующую, /week image - картинкой)
+/day [дата или день] - Расписание на дату, например /day 21.10 или /day пятница
+/ask [вопрос] - Задать вопрос AI-помощнику
+/usage - Использование AI-помощника
+
+🎤 Голосовое сообщение - вопрос AI-помощнику или ввод пары при заполнении расписания`,
+	"parity.odd":      "числитель",
+	"parity.even":     "знаменатель",
+	"admin.only":      "Команда доступна только администраторам.",
+	"error.internal":  "Что-то пошло не так. Попробуйте позже.",
+	"error.throttled": "Слишком много запросов, подождите немного.",
+	"answer.retry":    "Не получилось понять ответ, попробуйте еще раз.",
+
+	// Ввод расписания
+	"wizard.intro": "📝 Введите расписание. Сначала укажите день недели (например, 'Понедельник')\n" +
+		"Или отправьте /cancel для отмены",
+	"wizard.cancelled": "❌ Ввод расписания отменен",
+	"wizard.bad_day":   "Неверный день недели. Пожалуйста, выберите день из списка:",
+	"wizard.lessons_prompt": "📅 Введите пары для %s, по одной в сообщении.\n\n" +
+		"Можно писать свободно:\n" +
+		"матан в 9 в 101 у Иванова\n" +
+		"Физика 10:40-12:10 ауд 305\n\n" +
+		"По номеру пары (см. /bells):\n" +
+		"2 | Физика | 305 | Петров\n\n" +
+		"Или в формате:\n" +
+		"Математика | 09:00 | 10:30 | Ауд. 101 | Иванов И.И.\n\n" +
+		"Когда закончите, отправьте /done",
+	"wizard.day_done":     "День %s сохранен. Хотите добавить еще один день? (да/нет)",
+	"wizard.unknown_slot": "В вашем расписании звонков нет пары с таким номером. Посмотреть звонки: /bells",
+	"wizard.parse_failed": "Не удалось разобрать пару. Напишите, например:\n" +
+		"Физика 10:40-12:10 ауд 305 у Петрова\n" +
+		"или Название | Начало | Конец | Аудитория | Преподаватель",
+	"wizard.next_day":          "Выберите следующий день недели:",
+	"wizard.day_invalid":       "❌ %s не сохранен:\n",
+	"wizard.day_failed":        "❌ %s не сохранен из-за внутренней ошибки\n",
+	"wizard.day_warnings":      "⚠️ %s:\n",
+	"wizard.saved":             "✅ Расписание успешно сохранено!",
+	"wizard.saved_with_issues": "Расписание сохранено с замечаниями:\n\n",
+	"wizard.not_saved":         "Не все дни сохранены:\n\n",
+	"wizard.reenter_day":       "\nВыберите день, чтобы ввести его заново, или /cancel, чтобы выйти.",
+	"wizard.lesson_rejected":   "❌ Пара не добавлена:\n%s\nВведите ее заново.",
+	"wizard.lesson_added":      "✅ Пара добавлена: %s %s-%s. Введите следующую или /done для завершения",
+	"wizard.lesson_warnings":   "\n\n⚠️ Обратите внимание:\n",
+
+	// Вопросы о недостающих полях пары
+	"field.name.ask":     "Как называется предмет?",
+	"field.time.ask":     "Во сколько пара? Например: 10:40-12:10 или 9:00",
+	"field.location.ask": "В какой аудитории? Отправьте - если неизвестно",
+	"field.teacher.ask":  "Кто преподаватель? Отправьте - если неизвестно",
+
+	// Проблемы расписания
+	"issue.inverted_time": "• %s: время окончания (%s) не позже начала (%s)\n",
+	"issue.empty_name":    "• У пары не указано название\n",
+	"issue.overlap":       "• %s пересекается с парой %s (%s-%s)\n",
+	"issue.too_long":      "• %s длится больше %d ч\n",
+	"issue.long_gap":      "• Длинный перерыв между %s и %s (%s-%s)\n",
+
+	// Просмотр расписания
+	"schedule.error":         "Ошибка при получении расписания. Попробуйте позже.",
+	"schedule.menu":          "Выберите период для просмотра расписания:",
+	"schedule.today":         "Сегодня",
+	"schedule.tomorrow":      "Завтра",
+	"schedule.week":          "Вся неделя",
+	"schedule.next_week":     "Следующая неделя",
+	"schedule.pick_date":     "📆 Выбрать дату",
+	"day.holiday":            "%s, %s - выходной: %s 🎉",
+	"day.empty":              "На %s, %s пар нет 🎉",
+	"day.title":              "📅 Расписание на %s, %s (%s):\n\n",
+	"day.lesson_slot":        "%d. %s (%d пара)\n",
+	"day.calendar":           "📆 Выберите дату:",
+	"day.bad_date":           "Не понял дату. Примеры: /day 2026-10-21, /day 21.10, /day пятница",
+	"week.usage":             "Используйте /week, /week next или /week image",
+	"week.image_title":       "Расписание на неделю %s - %s",
+	"week.image_error":       "Не удалось нарисовать расписание. Попробуйте текстом: /week",
+	"week.title":             "📅 Ваше расписание на неделю:\n\n",
+	"week.title_next":        "📅 Ваше расписание на следующую неделю:\n\n",
+	"week.no_lessons":        "Пар нет",
+	"week.next_button":       "Следующая неделя »",
+	"week.current_button":    "« Текущая неделя",
+	"transfer.warning":       "⚠️ После пары %s нужно перейти из корпуса «%s» в «%s», перерыв всего %s\n",
+	"duration.minutes":       "%d мин",
+	"duration.hours":         "%d ч",
+	"duration.hours_minutes": "%d ч %d мин",
+
+	// /now и /timezone
+	"now.current":      "🟢 Сейчас: %s\n",
+	"now.current_ends": "   до конца %s (в %s)\n",
+	"now.no_current":   "⚪️ Сейчас пары нет\n",
+	"now.no_next":      "\nБлижайшие две недели пар нет 🎉",
+	"now.next":         "\n⏭ Дальше: %s\n",
+	"now.next_today":   "   в %s, через %s\n",
+	"now.next_later":   "   %s, %s в %s\n",
+	"now.break":        "\n☕️ Перерыв: %s\n",
+	"timezone.current": "🌍 Ваш часовой пояс: %s\nЧтобы изменить, отправьте, например:\n/timezone Europe/Moscow",
+	"timezone.unknown": "Неизвестный часовой пояс. Пример: Europe/Moscow, Asia/Yekaterinburg",
+	"timezone.error":   "Не удалось сохранить часовой пояс. Попробуйте позже.",
+	"timezone.saved":   "✅ Часовой пояс: %s",
+
+	// /language
+	"language.choose":  "🌐 Язык интерфейса: %s\nВыберите язык:",
+	"language.unknown": "Неизвестный язык. Доступны: ru, en",
+	"language.error":   "Не удалось сохранить язык. Попробуйте позже.",
+	"language.saved":   "✅ Язык интерфейса: %s",
+
+	// Редактирование
+	"edit.pick_day":       "✏️ Выберите день, пары которого хотите изменить:",
+	"edit.pick_lesson":    "✏️ %s: выберите пару",
+	"edit.no_lessons":     "На %s пар нет",
+	"edit.not_found":      "Пара не найдена. Возможно, она уже удалена.",
+	"edit.rejected":       "❌ Изменение не сохранено:\n",
+	"edit.updated":        "✅ Пара обновлена:\n\n",
+	"edit.warnings":       "\n⚠️ Обратите внимание:\n",
+	"edit.field.name":     "Название",
+	"edit.field.time":     "Время",
+	"edit.field.location": "Аудитория",
+	"edit.field.teacher":  "Преподаватель",
+	"edit.up":             "⬆️ Выше",
+	"edit.down":           "⬇️ Ниже",
+	"edit.delete":         "🗑 Удалить",
+	"edit.back":           "« Назад",
+
+	// Звонки
+	"bells.not_configured": "Расписания звонков не настроены.",
+	"bells.error":          "Не удалось получить расписание звонков. Попробуйте позже.",
+	"bells.not_selected":   "🔔 Расписание звонков не выбрано.",
+	"bells.selected":       "✅ Расписание звонков выбрано.\n\n",
+	"bells.title":          "🔔 Звонки (%s):\n\n",
+	"bells.slot":           "%d пара: %s - %s\n",
+
+	// AI-помощник
+	"ask.empty":             "Пожалуйста, задайте вопрос после команды /ask",
+	"ask.error":             "Произошла ошибка при обработке вопроса.",
+	"limit.daily":           "Дневной лимит вопросов к AI-помощнику исчерпан. Попробуйте завтра.",
+	"limit.monthly":         "Месячный лимит вопросов к AI-помощнику исчерпан.",
+	"limit.rate":            "Слишком много вопросов подряд. Попробуйте через %d сек.",
+	"usage.error":           "Не удалось получить статистику. Попробуйте позже.",
+	"usage.text":            "🤖 Использование AI-помощника:\n\nСегодня: %s\nВ этом месяце: %s\nНе чаще: %s",
+	"usage.unlimited":       "без ограничений",
+	"usage.quota":           "%d из %d",
+	"usage.quota_unlimited": "%d (без ограничений)",
+	"usage.rate":            "%d запросов в минуту",
+
+	// Голосовые сообщения
+	"voice.too_long":          "Голосовое сообщение слишком длинное. Попробуйте короче.",
+	"voice.download_failed":   "Не удалось получить голосовое сообщение.",
+	"voice.transcribe_failed": "Не удалось распознать голосовое сообщение.",
+	"voice.empty":             "Не удалось разобрать слова. Попробуйте еще раз.",
+
+	// Преподаватели
+	"teacher.usage":          "Укажите фамилию: /teacher Иванов",
+	"teacher.error":          "Ошибка при поиске преподавателя. Попробуйте позже.",
+	"teacher.not_found":      "Преподаватель не найден в справочнике.",
+	"teacher.no_lessons":     "\nНа этой неделе пар с преподавателем нет\n\n",
+	"teacher.week":           "\n📅 Пары на этой неделе:\n",
+	"teacher.office_hours":   "🕒 Часы приема: %s\n",
+	"teachers.error":         "Не удалось получить справочник. Попробуйте позже.",
+	"teachers.empty":         "Справочник преподавателей пуст.",
+	"teachers.title":         "👨‍🏫 Преподаватели:\n\n",
+	"teacher.add_usage":      "Формат: /addteacher ФИО | Кафедра | Контакты | Часы приема",
+	"teacher.added":          "✅ Преподаватель добавлен:\n\n",
+	"teacher.edit_usage":     "Формат: /editteacher фамилия или ID | ФИО | Кафедра | Контакты | Часы приема\nПустое поле не меняется, - очищает его.",
+	"teacher.updated":        "✏️ Преподаватель изменен:\n\n",
+	"teacher.delete_missing": "Преподаватель не найден.",
+	"teacher.ambiguous":      "Найдено несколько преподавателей, укажите ID из /teacher.",
+	"teacher.delete_error":   "Не удалось удалить преподавателя. Попробуйте позже.",
+	"teacher.deleted":        "🗑 %s удален из справочника",
+
+	// Аудитории
+	"where.usage":     "Укажите аудиторию: /where 305",
+	"where.not_found": "Аудитория не найдена в справочнике.",
+	"where.title":     "Ауд. %s, %d этаж",
+
+	// Уровень логирования
+	"loglevel.current": "Уровень логирования: %s\nИзменить: /loglevel debug|info|warn|error",
+	"loglevel.invalid": "Неизвестный уровень. Допустимо: debug, info, warn, error.",
+	"loglevel.set":     "✅ Уровень логирования: %s",
+
+	// Профиль
+	"profile.title":      "👤 Профиль",
+	"profile.id":         "ID: %s",
+	"profile.username":   "Username: %s",
+	"profile.name":       "Имя: %s",
+	"profile.tg_lang":    "Язык Telegram: %s",
+	"profile.lang":       "Язык бота: %s",
+	"profile.role":       "Роль: %s",
+	"profile.timezone":   "Часовой пояс: %s",
+	"profile.bells":      "Расписание звонков: %s",
+	"profile.created":    "Зарегистрирован: %s",
+	"profile.last_seen":  "Последняя активность: %s",
+	"profile.role_admin": "администратор",
+	"profile.role_user":  "пользователь",
+	"profile.empty":      "—",
+	"profile.error":      "Не удалось загрузить профиль. Попробуйте позже.",
+
+	// Мои данные
+	"mydata.caption":     "📦 Все данные, которые бот хранит о вас",
+	"mydata.error":       "Не удалось выгрузить данные. Попробуйте позже.",
+	"deleteme.confirm":   "⚠️ Удалить все ваши данные: профиль, настройки, расписание и счетчики AI? Это действие нельзя отменить.",
+	"deleteme.yes":       "🗑 Удалить",
+	"deleteme.no":        "Отмена",
+	"deleteme.done":      "✅ Ваши данные удалены. Если продолжите пользоваться ботом, он начнет хранить данные заново.",
+	"deleteme.cancelled": "Удаление отменено.",
+	"deleteme.error":     "Не удалось удалить данные. Попробуйте позже.",
+
+	// Рассылка
+	"broadcast.usage":          "Укажите текст: /broadcast Занятия 21.10 переносятся на 12:10\nТолько для группы: /broadcast #main Занятия 21.10 переносятся на 12:10",
+	"broadcast.unknown_group":  "Группа «%s» не найдена. Доступные группы: %s",
+	"broadcast.preview_group":  "📣 Предпросмотр рассылки для группы %s, получателей: %d",
+	"broadcast.too_long":       "Текст рассылки длиннее %d символов.",
+	"broadcast.preview":        "📣 Предпросмотр рассылки, получателей: %d",
+	"broadcast.send":           "📤 Отправить",
+	"broadcast.cancel":         "Отмена",
+	"broadcast.cancelled":      "Рассылка отменена.",
+	"broadcast.expired":        "Рассылка уже отправлена или отменена.",
+	"broadcast.sending":        "⏳ Рассылка начата, получателей: %d",
+	"broadcast.report":         "📣 Рассылка завершена\nДоставлено: %d\nЗаблокировали бота: %d\nНе доставлено: %d",
+	"broadcast.report_stopped": "\nНе отправлено из-за остановки бота: %d. Повторите рассылку после запуска.",
+	"broadcast.error":          "Не удалось начать рассылку. Попробуйте позже.",
+}
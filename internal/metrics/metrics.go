@@ -0,0 +1,101 @@
+package metrics
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "tg_bot"
+
+// Metrics - метрики бота в формате Prometheus
+type Metrics struct {
+	registry *prometheus.Registry
+
+	updates         *prometheus.CounterVec
+	handlerDuration *prometheus.HistogramVec
+	aiCalls         *prometheus.CounterVec
+	aiTokens        *prometheus.CounterVec
+}
+
+// New создает метрики в отдельном реестре вместе со стандартными метриками процесса и Go
+func New() *Metrics {
+	m := &Metrics{
+		registry: prometheus.NewRegistry(),
+		updates: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "updates_total",
+			Help:      "Handled Telegram updates by command or update kind.",
+		}, []string{"command"}),
+		handlerDuration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Name:      "handler_duration_seconds",
+			Help:      "Time spent handling an update.",
+			Buckets:   []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30},
+		}, []string{"command"}),
+		aiCalls: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "ai_calls_total",
+			Help:      "OpenAI API calls by operation and result.",
+		}, []string{"operation", "result"}),
+		aiTokens: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Name:      "ai_tokens_total",
+			Help:      "OpenAI tokens used by operation and kind (prompt, completion).",
+		}, []string{"operation", "kind"}),
+	}
+
+	m.registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		m.updates,
+		m.handlerDuration,
+		m.aiCalls,
+		m.aiTokens,
+	)
+
+	return m
+}
+
+// Handler отдает метрики для /metrics
+func (m *Metrics) Handler() http.Handler {
+	return promhttp.HandlerFor(m.registry, promhttp.HandlerOpts{})
+}
+
+// ObserveUpdate учитывает обработанный апдейт
+func (m *Metrics) ObserveUpdate(command string, duration time.Duration) {
+	m.updates.WithLabelValues(command).Inc()
+	m.handlerDuration.WithLabelValues(command).Observe(duration.Seconds())
+}
+
+// ObserveAICall учитывает обращение к OpenAI и израсходованные токены
+func (m *Metrics) ObserveAICall(operation string, err error, promptTokens, completionTokens int) {
+	result := "ok"
+	if err != nil {
+		result = "error"
+	}
+	m.aiCalls.WithLabelValues(operation, result).Inc()
+
+	if promptTokens > 0 {
+		m.aiTokens.WithLabelValues(operation, "prompt").Add(float64(promptTokens))
+	}
+	if completionTokens > 0 {
+		m.aiTokens.WithLabelValues(operation, "completion").Add(float64(completionTokens))
+	}
+}
+
+// RegisterSessions добавляет датчик активных сессий пошагового ввода (FSM) указанного вида.
+// value вызывается при каждом чтении метрик.
+func (m *Metrics) RegisterSessions(kind string, value func() int) {
+	m.registry.MustRegister(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace:   namespace,
+		Name:        "active_sessions",
+		Help:        "Users currently in a multi-step dialog.",
+		ConstLabels: prometheus.Labels{"kind": kind},
+	}, func() float64 {
+		return float64(value())
+	}))
+}
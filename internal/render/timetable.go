@@ -0,0 +1,344 @@
+package render
+
+import (
+	"bytes"
+	"fmt"
+	"hash/fnv"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/png"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/image/font"
+	"golang.org/x/image/font/gofont/gobold"
+	"golang.org/x/image/font/gofont/goregular"
+	"golang.org/x/image/font/opentype"
+	"golang.org/x/image/math/fixed"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+// Размеры сетки в пикселях
+const (
+	padding      = 16
+	titleHeight  = 36
+	headerHeight = 40
+	timeWidth    = 72
+	dayWidth     = 180
+	rowHeight    = 76
+	cellInset    = 3
+	cellPadding  = 6
+	lineHeight   = 16
+)
+
+var (
+	background = color.RGBA{0xff, 0xff, 0xff, 0xff}
+	gridColor  = color.RGBA{0xdd, 0xdd, 0xdd, 0xff}
+	headerFill = color.RGBA{0xf2, 0xf2, 0xf2, 0xff}
+	textColor  = color.RGBA{0x22, 0x22, 0x22, 0xff}
+	mutedColor = color.RGBA{0x77, 0x77, 0x77, 0xff}
+	noteFill   = color.RGBA{0xfd, 0xf3, 0xd0, 0xff}
+
+	// subjectColors - палитра для заливки пар, цвет выбирается по названию предмета
+	subjectColors = []color.RGBA{
+		{0xcf, 0xe8, 0xfc, 0xff},
+		{0xd4, 0xf0, 0xd2, 0xff},
+		{0xfd, 0xdf, 0xc4, 0xff},
+		{0xe6, 0xd8, 0xf5, 0xff},
+		{0xfb, 0xd3, 0xdc, 0xff},
+		{0xd2, 0xf1, 0xee, 0xff},
+		{0xf5, 0xeb, 0xc4, 0xff},
+		{0xe0, 0xe4, 0xea, 0xff},
+	}
+)
+
+// TimetableDay - один столбец сетки
+type TimetableDay struct {
+	// Title - заголовок столбца, например "Пн 20.10"
+	Title string
+	// Note - текст на весь день вместо пар, например название праздника
+	Note    string
+	Lessons []domain.Lesson
+}
+
+// Timetable описывает неделю для отрисовки
+type Timetable struct {
+	Title string
+	Days  []TimetableDay
+	// Bells задает строки сетки. Пары, не попавшие в звонки, добавляют свои строки.
+	Bells domain.BellSchedule
+}
+
+// row - строка сетки (временной интервал)
+type row struct {
+	start, end time.Time
+}
+
+type faces struct {
+	regular, bold font.Face
+}
+
+var (
+	parseFontsOnce sync.Once
+	regularFont    *opentype.Font
+	boldFont       *opentype.Font
+	parseFontsErr  error
+)
+
+// newFaces создает начертания из встроенных шрифтов Go (в них есть кириллица).
+// Разобранные шрифты общие, а font.Face не потокобезопасен, поэтому создается на каждый вызов.
+func newFaces() (faces, error) {
+	parseFontsOnce.Do(func() {
+		if regularFont, parseFontsErr = opentype.Parse(goregular.TTF); parseFontsErr != nil {
+			return
+		}
+		boldFont, parseFontsErr = opentype.Parse(gobold.TTF)
+	})
+	if parseFontsErr != nil {
+		return faces{}, parseFontsErr
+	}
+
+	regular, err := opentype.NewFace(regularFont, &opentype.FaceOptions{Size: 12, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return faces{}, err
+	}
+	bold, err := opentype.NewFace(boldFont, &opentype.FaceOptions{Size: 13, DPI: 72, Hinting: font.HintingFull})
+	if err != nil {
+		return faces{}, err
+	}
+	return faces{regular: regular, bold: bold}, nil
+}
+
+// TimetablePNG рисует неделю сеткой "дни × пары" и возвращает PNG
+func TimetablePNG(t Timetable) ([]byte, error) {
+	if len(t.Days) == 0 {
+		return nil, fmt.Errorf("timetable has no days")
+	}
+
+	fc, err := newFaces()
+	if err != nil {
+		return nil, fmt.Errorf("load fonts: %w", err)
+	}
+
+	rows := timetableRows(t)
+	gridTop := padding + titleHeight + headerHeight
+	width := padding*2 + timeWidth + dayWidth*len(t.Days)
+	height := gridTop + rowHeight*max(len(rows), 1) + padding
+
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	draw.Draw(img, img.Bounds(), image.NewUniform(background), image.Point{}, draw.Src)
+
+	drawText(img, fc.bold, t.Title, padding, padding+20, textColor)
+
+	// Заголовки дней
+	for i, day := range t.Days {
+		x := padding + timeWidth + i*dayWidth
+		fillRect(img, image.Rect(x, padding+titleHeight, x+dayWidth, gridTop), headerFill)
+		drawText(img, fc.bold, fit(fc.bold, day.Title, dayWidth-2*cellPadding), x+cellPadding, padding+titleHeight+25, textColor)
+	}
+
+	// Время строк
+	for r, rw := range rows {
+		y := gridTop + r*rowHeight
+		drawText(img, fc.regular, rw.start.Format("15:04"), padding+cellPadding, y+20, textColor)
+		drawText(img, fc.regular, rw.end.Format("15:04"), padding+cellPadding, y+20+lineHeight, mutedColor)
+	}
+
+	drawGrid(img, len(t.Days), len(rows), gridTop, height)
+
+	// Пары и праздники
+	for i, day := range t.Days {
+		x := padding + timeWidth + i*dayWidth
+
+		if day.Note != "" {
+			cell := image.Rect(x+cellInset, gridTop+cellInset, x+dayWidth-cellInset, height-padding-cellInset)
+			fillRect(img, cell, noteFill)
+			drawWrapped(img, fc.bold, day.Note, cell, 4, textColor)
+			continue
+		}
+
+		cells := make(map[int][]domain.Lesson)
+		for _, lesson := range day.Lessons {
+			r := rowIndex(rows, t.Bells, lesson)
+			cells[r] = append(cells[r], lesson)
+		}
+
+		for r, lessons := range cells {
+			y := gridTop + r*rowHeight
+			cell := image.Rect(x+cellInset, y+cellInset, x+dayWidth-cellInset, y+rowHeight-cellInset)
+			fillRect(img, cell, subjectColor(lessons[0].Name))
+			drawLessons(img, fc, lessons, cell)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("encode png: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// timetableRows собирает строки сетки из звонков и времени пар, не совпадающих со звонками
+func timetableRows(t Timetable) []row {
+	seen := make(map[string]bool)
+	var rows []row
+
+	add := func(start, end time.Time) {
+		key := start.Format("15:04")
+		if seen[key] {
+			return
+		}
+		seen[key] = true
+		rows = append(rows, row{start: start, end: end})
+	}
+
+	for _, slot := range t.Bells.Slots {
+		add(slot.StartTime, slot.EndTime)
+	}
+	for _, day := range t.Days {
+		for _, lesson := range day.Lessons {
+			if _, ok := t.Bells.Slot(lesson.Slot); ok {
+				continue
+			}
+			add(lesson.StartTime, lesson.EndTime)
+		}
+	}
+
+	sort.SliceStable(rows, func(i, j int) bool {
+		return rows[i].start.Before(rows[j].start)
+	})
+	return rows
+}
+
+// rowIndex находит строку пары: по номеру пары в звонках или по времени начала
+func rowIndex(rows []row, bells domain.BellSchedule, lesson domain.Lesson) int {
+	start := lesson.StartTime.Format("15:04")
+	if slot, ok := bells.Slot(lesson.Slot); ok {
+		start = slot.StartTime.Format("15:04")
+	}
+
+	for i, rw := range rows {
+		if rw.start.Format("15:04") == start {
+			return i
+		}
+	}
+	return 0
+}
+
+// drawLessons пишет в ячейку названия пар (через " / ", если их несколько), аудиторию и преподавателя
+func drawLessons(img *image.RGBA, fc faces, lessons []domain.Lesson, cell image.Rectangle) {
+	names := make([]string, 0, len(lessons))
+	for _, lesson := range lessons {
+		names = append(names, lesson.Name)
+	}
+
+	textWidth := cell.Dx() - 2*cellPadding
+	y := cell.Min.Y + cellPadding + 12
+	drawText(img, fc.bold, fit(fc.bold, strings.Join(names, " / "), textWidth), cell.Min.X+cellPadding, y, textColor)
+
+	details := []string{lessons[0].Location, lessons[0].Teacher}
+	for _, detail := range details {
+		if detail == "" {
+			continue
+		}
+		y += lineHeight
+		if y > cell.Max.Y-cellPadding {
+			return
+		}
+		drawText(img, fc.regular, fit(fc.regular, detail, textWidth), cell.Min.X+cellPadding, y, mutedColor)
+	}
+}
+
+// drawGrid рисует линии сетки
+func drawGrid(img *image.RGBA, days, rows, gridTop, height int) {
+	left := padding
+	right := padding + timeWidth + dayWidth*days
+	top := padding + titleHeight
+
+	for r := 0; r <= rows; r++ {
+		y := gridTop + r*rowHeight
+		fillRect(img, image.Rect(left, y, right, y+1), gridColor)
+	}
+	fillRect(img, image.Rect(left, top, right, top+1), gridColor)
+
+	bottom := height - padding
+	for d := 0; d <= days; d++ {
+		x := padding + timeWidth + d*dayWidth
+		fillRect(img, image.Rect(x, top, x+1, bottom), gridColor)
+	}
+	fillRect(img, image.Rect(left, top, left+1, bottom), gridColor)
+}
+
+// drawWrapped пишет текст в прямоугольник с переносом по словам, не больше maxLines строк
+func drawWrapped(img *image.RGBA, face font.Face, text string, cell image.Rectangle, maxLines int, c color.Color) {
+	width := cell.Dx() - 2*cellPadding
+	y := cell.Min.Y + cellPadding + 12
+
+	var line string
+	lines := 0
+	flush := func() {
+		drawText(img, face, fit(face, line, width), cell.Min.X+cellPadding, y, c)
+		y += lineHeight
+		lines++
+		line = ""
+	}
+
+	for _, word := range strings.Fields(text) {
+		candidate := strings.TrimSpace(line + " " + word)
+		if line != "" && font.MeasureString(face, candidate).Ceil() > width {
+			flush()
+			if lines == maxLines {
+				return
+			}
+			candidate = word
+		}
+		line = candidate
+	}
+	if line != "" {
+		flush()
+	}
+}
+
+// fit обрезает текст с многоточием, чтобы он поместился в width пикселей
+func fit(face font.Face, text string, width int) string {
+	if font.MeasureString(face, text).Ceil() <= width {
+		return text
+	}
+
+	runes := []rune(text)
+	for len(runes) > 0 {
+		runes = runes[:len(runes)-1]
+		candidate := strings.TrimSpace(string(runes)) + "…"
+		if font.MeasureString(face, candidate).Ceil() <= width {
+			return candidate
+		}
+	}
+	return ""
+}
+
+// drawText пишет строку с базовой линией в точке (x, y)
+func drawText(img *image.RGBA, face font.Face, text string, x, y int, c color.Color) {
+	d := font.Drawer{
+		Dst:  img,
+		Src:  image.NewUniform(c),
+		Face: face,
+		Dot:  fixed.P(x, y),
+	}
+	d.DrawString(text)
+}
+
+// fillRect заливает прямоугольник цветом
+func fillRect(img *image.RGBA, rect image.Rectangle, c color.Color) {
+	draw.Draw(img, rect, image.NewUniform(c), image.Point{}, draw.Src)
+}
+
+// subjectColor выбирает цвет пары по названию, одинаковые предметы окрашены одинаково
+func subjectColor(name string) color.RGBA {
+	h := fnv.New32a()
+	h.Write([]byte(strings.ToLower(strings.TrimSpace(name))))
+	return subjectColors[h.Sum32()%uint32(len(subjectColors))]
+}
@@ -0,0 +1,166 @@
+package render
+
+import (
+	"bytes"
+	"flag"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+var update = flag.Bool("update", false, "перезаписать эталонные PNG в testdata")
+
+func clock(h, m int) time.Time {
+	return time.Date(0, 1, 1, h, m, 0, 0, time.UTC)
+}
+
+func testBells() domain.BellSchedule {
+	return domain.BellSchedule{
+		Name: "main",
+		Slots: []domain.BellSlot{
+			{Number: 1, StartTime: clock(8, 30), EndTime: clock(10, 0)},
+			{Number: 2, StartTime: clock(10, 10), EndTime: clock(11, 40)},
+			{Number: 3, StartTime: clock(12, 10), EndTime: clock(13, 40)},
+		},
+	}
+}
+
+func TestTimetablePNGGolden(t *testing.T) {
+	tests := []struct {
+		name  string
+		table Timetable
+	}{
+		{
+			name: "week",
+			table: Timetable{
+				Title: "Неделя 20.10 - 25.10",
+				Bells: testBells(),
+				Days: []TimetableDay{
+					{Title: "Пн 20.10", Lessons: []domain.Lesson{
+						{Name: "Математический анализ", Slot: 1, StartTime: clock(8, 30), EndTime: clock(10, 0), Location: "305", Teacher: "Иванов И.И."},
+						{Name: "Физика", Slot: 3, StartTime: clock(12, 10), EndTime: clock(13, 40), Location: "101"},
+					}},
+					{Title: "Вт 21.10", Lessons: []domain.Lesson{
+						{Name: "История", Slot: 2, StartTime: clock(10, 10), EndTime: clock(11, 40)},
+						{Name: "Английский", Slot: 2, StartTime: clock(10, 10), EndTime: clock(11, 40)},
+					}},
+					{Title: "Ср 22.10"},
+				},
+			},
+		},
+		{
+			name: "holiday_and_custom_time",
+			table: Timetable{
+				Title: "Week 03.11 - 08.11",
+				Bells: testBells(),
+				Days: []TimetableDay{
+					{Title: "Mon 03.11", Lessons: []domain.Lesson{
+						{Name: "Programming", StartTime: clock(18, 0), EndTime: clock(19, 30), Location: "lab 2"},
+					}},
+					{Title: "Tue 04.11", Note: "День народного единства"},
+				},
+			},
+		},
+		{
+			name: "no_bells",
+			table: Timetable{
+				Title: "Неделя",
+				Days: []TimetableDay{
+					{Title: "Пт 24.10", Lessons: []domain.Lesson{
+						{Name: "Очень длинное название предмета, которое не помещается в ячейку", StartTime: clock(9, 0), EndTime: clock(10, 30)},
+					}},
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := TimetablePNG(tt.table)
+			if err != nil {
+				t.Fatalf("TimetablePNG: %v", err)
+			}
+
+			golden := filepath.Join("testdata", tt.name+".png")
+			if *update {
+				if err := os.WriteFile(golden, got, 0o644); err != nil {
+					t.Fatal(err)
+				}
+				return
+			}
+
+			want, err := os.ReadFile(golden)
+			if err != nil {
+				t.Fatalf("read golden (run with -update to create): %v", err)
+			}
+			if !samePixels(t, got, want) {
+				t.Errorf("image differs from %s; inspect it and run with -update if the change is intended", golden)
+			}
+		})
+	}
+}
+
+func TestTimetablePNGNoDays(t *testing.T) {
+	if _, err := TimetablePNG(Timetable{Title: "empty"}); err == nil {
+		t.Fatal("expected error for a timetable without days")
+	}
+}
+
+// Начертания создаются на каждый вызов, поэтому параллельная отрисовка безопасна (проверяется с -race)
+func TestTimetablePNGConcurrent(t *testing.T) {
+	table := Timetable{
+		Title: "Неделя",
+		Bells: testBells(),
+		Days: []TimetableDay{{Title: "Пн", Lessons: []domain.Lesson{
+			{Name: "Физика", Slot: 1, StartTime: clock(8, 30), EndTime: clock(10, 0)},
+		}}},
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 16; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := TimetablePNG(table); err != nil {
+				t.Error(err)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+func samePixels(t *testing.T, a, b []byte) bool {
+	t.Helper()
+	imgA, err := png.Decode(bytes.NewReader(a))
+	if err != nil {
+		t.Fatalf("decode result: %v", err)
+	}
+	imgB, err := png.Decode(bytes.NewReader(b))
+	if err != nil {
+		t.Fatalf("decode golden: %v", err)
+	}
+	if imgA.Bounds() != imgB.Bounds() {
+		return false
+	}
+	return equalImages(imgA, imgB)
+}
+
+func equalImages(a, b image.Image) bool {
+	bounds := a.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			r1, g1, b1, a1 := a.At(x, y).RGBA()
+			r2, g2, b2, a2 := b.At(x, y).RGBA()
+			if r1 != r2 || g1 != g2 || b1 != b2 || a1 != a2 {
+				return false
+			}
+		}
+	}
+	return true
+}
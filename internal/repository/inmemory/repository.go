@@ -2,24 +2,41 @@ package inmemory
 
 import (
 	"context"
+	"sort"
 	"sync"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
 )
 
 type InMemoryRepository struct {
-	mu    sync.RWMutex
-	users map[int64]struct{}
+	mu       sync.RWMutex
+	users    map[int64]domain.User
+	aiUsage  map[int64]domain.AIUsage
+	settings map[int64]domain.UserSettings
+	teachers map[string]domain.Teacher
 }
 
 func New() *InMemoryRepository {
 	return &InMemoryRepository{
-		users: make(map[int64]struct{}),
+		users:    make(map[int64]domain.User),
+		aiUsage:  make(map[int64]domain.AIUsage),
+		settings: make(map[int64]domain.UserSettings),
+		teachers: make(map[string]domain.Teacher),
 	}
 }
 
+// Ping всегда успешен: хранилище в памяти процесса
+func (r *InMemoryRepository) Ping(ctx context.Context) error {
+	return nil
+}
+
 func (r *InMemoryRepository) CreateUser(ctx context.Context, userID int64) error {
 	r.mu.Lock()
 	defer r.mu.Unlock()
-	r.users[userID] = struct{}{}
+	if _, exists := r.users[userID]; !exists {
+		r.users[userID] = domain.User{ID: userID, CreatedAt: time.Now()}
+	}
 	return nil
 }
 
@@ -29,3 +46,128 @@ func (r *InMemoryRepository) UserExists(ctx context.Context, userID int64) (bool
 	_, exists := r.users[userID]
 	return exists, nil
 }
+
+func (r *InMemoryRepository) GetUser(ctx context.Context, userID int64) (domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user, ok := r.users[userID]
+	if !ok {
+		return domain.User{ID: userID}, nil
+	}
+	return user, nil
+}
+
+func (r *InMemoryRepository) SaveUser(ctx context.Context, user domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.users[user.ID] = user
+	return nil
+}
+
+func (r *InMemoryRepository) UpsertUserProfile(ctx context.Context, profile domain.User) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, exists := r.users[profile.ID]
+	if !exists {
+		user = domain.User{ID: profile.ID, CreatedAt: profile.LastSeen}
+	}
+	user.Username = profile.Username
+	user.FirstName = profile.FirstName
+	user.LastName = profile.LastName
+	user.LanguageCode = profile.LanguageCode
+	user.LastSeen = profile.LastSeen
+	// Апдейт от пользователя означает, что бот снова может ему писать
+	user.Blocked = false
+	r.users[profile.ID] = user
+	return nil
+}
+
+func (r *InMemoryRepository) ListUsers(ctx context.Context) ([]domain.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	users := make([]domain.User, 0, len(r.users))
+	for _, user := range r.users {
+		users = append(users, user)
+	}
+	sort.Slice(users, func(i, j int) bool { return users[i].ID < users[j].ID })
+	return users, nil
+}
+
+func (r *InMemoryRepository) SetUserBlocked(ctx context.Context, userID int64, blocked bool) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, exists := r.users[userID]
+	if !exists {
+		return nil
+	}
+	user.Blocked = blocked
+	r.users[userID] = user
+	return nil
+}
+
+func (r *InMemoryRepository) DeleteUser(ctx context.Context, userID int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.users, userID)
+	delete(r.aiUsage, userID)
+	delete(r.settings, userID)
+	return nil
+}
+
+func (r *InMemoryRepository) GetAIUsage(ctx context.Context, userID int64) (domain.AIUsage, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	usage, ok := r.aiUsage[userID]
+	if !ok {
+		return domain.AIUsage{UserID: userID}, nil
+	}
+	return usage, nil
+}
+
+func (r *InMemoryRepository) SaveAIUsage(ctx context.Context, usage domain.AIUsage) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.aiUsage[usage.UserID] = usage
+	return nil
+}
+
+func (r *InMemoryRepository) GetSettings(ctx context.Context, userID int64) (domain.UserSettings, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	settings, ok := r.settings[userID]
+	if !ok {
+		return domain.UserSettings{UserID: userID}, nil
+	}
+	return settings, nil
+}
+
+func (r *InMemoryRepository) SaveSettings(ctx context.Context, settings domain.UserSettings) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.settings[settings.UserID] = settings
+	return nil
+}
+
+func (r *InMemoryRepository) SaveTeacher(ctx context.Context, teacher domain.Teacher) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.teachers[teacher.ID] = teacher
+	return nil
+}
+
+func (r *InMemoryRepository) DeleteTeacher(ctx context.Context, id string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.teachers, id)
+	return nil
+}
+
+func (r *InMemoryRepository) ListTeachers(ctx context.Context) ([]domain.Teacher, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	teachers := make([]domain.Teacher, 0, len(r.teachers))
+	for _, t := range r.teachers {
+		teachers = append(teachers, t)
+	}
+	return teachers, nil
+}
@@ -2,17 +2,80 @@ package service
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/polyk005/tg_bot/internal/domain"
 	openai "github.com/sashabaranov/go-openai"
 )
 
+// AIObserver получает сведения о каждом обращении к OpenAI (например, для метрик)
+type AIObserver interface {
+	ObserveAICall(operation string, err error, promptTokens, completionTokens int)
+}
+
+// Операции AIService для AIObserver
+const (
+	AIOperationQuestion      = "question"
+	AIOperationTranscription = "transcription"
+	AIOperationLessonParse   = "lesson_parse"
+)
+
+// AIParams - параметры модели для ответов на вопросы и разбора пар
+type AIParams struct {
+	Model string
+	// Temperature 0 - значение по умолчанию модели
+	Temperature float32
+	// MaxTokens ограничивает длину ответа, 0 - без ограничения
+	MaxTokens int
+}
+
+// DefaultAIParams используются, пока не заданы другие
+var DefaultAIParams = AIParams{Model: openai.GPT3Dot5Turbo}
+
 type AIService struct {
-	client *openai.Client
+	client   *openai.Client
+	observer AIObserver
+
+	mu     sync.RWMutex
+	params AIParams
 }
 
-func NewAIService(apiKey string) *AIService {
+// NewAIService создает клиент OpenAI. observer может быть nil.
+func NewAIService(apiKey string, observer AIObserver) *AIService {
 	return &AIService{
-		client: openai.NewClient(apiKey),
+		client:   openai.NewClient(apiKey),
+		observer: observer,
+		params:   DefaultAIParams,
+	}
+}
+
+// SetParams меняет параметры модели для следующих запросов. Пустая модель - модель по умолчанию.
+func (ai *AIService) SetParams(params AIParams) {
+	if params.Model == "" {
+		params.Model = DefaultAIParams.Model
+	}
+
+	ai.mu.Lock()
+	defer ai.mu.Unlock()
+	ai.params = params
+}
+
+// Params возвращает текущие параметры модели
+func (ai *AIService) Params() AIParams {
+	ai.mu.RLock()
+	defer ai.mu.RUnlock()
+	return ai.params
+}
+
+// observe передает результат обращения к OpenAI наблюдателю, если он задан
+func (ai *AIService) observe(operation string, err error, usage openai.Usage) {
+	if ai.observer != nil {
+		ai.observer.ObserveAICall(operation, err, usage.PromptTokens, usage.CompletionTokens)
 	}
 }
 
@@ -23,10 +86,13 @@ func (ai *AIService) ProcessScheduleImage(ctx context.Context, imageURL string)
 }
 
 func (ai *AIService) HandleQuestion(ctx context.Context, question string) (string, error) {
+	params := ai.Params()
 	resp, err := ai.client.CreateChatCompletion(
-		context.Background(),
+		ctx,
 		openai.ChatCompletionRequest{
-			Model: openai.GPT3Dot5Turbo,
+			Model:       params.Model,
+			Temperature: params.Temperature,
+			MaxTokens:   params.MaxTokens,
 			Messages: []openai.ChatCompletionMessage{
 				{
 					Role:    openai.ChatMessageRoleSystem,
@@ -39,10 +105,92 @@ func (ai *AIService) HandleQuestion(ctx context.Context, question string) (strin
 			},
 		},
 	)
+	ai.observe(AIOperationQuestion, err, resp.Usage)
 
 	if err != nil {
 		return "", err
 	}
+	if len(resp.Choices) == 0 {
+		return "", fmt.Errorf("empty completion")
+	}
 
 	return resp.Choices[0].Message.Content, nil
 }
+
+// Transcribe распознает речь через Whisper. Пустой language - язык определяется автоматически.
+func (ai *AIService) Transcribe(ctx context.Context, audio io.Reader, filename, language string) (string, error) {
+	resp, err := ai.client.CreateTranscription(ctx, openai.AudioRequest{
+		Model:    openai.Whisper1,
+		FilePath: filename,
+		Reader:   audio,
+		Language: language,
+	})
+	ai.observe(AIOperationTranscription, err, openai.Usage{})
+	if err != nil {
+		return "", err
+	}
+
+	return strings.TrimSpace(resp.Text), nil
+}
+
+// lessonJSON - формат ответа модели при разборе пары
+type lessonJSON struct {
+	Name     string `json:"name"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Location string `json:"location"`
+	Teacher  string `json:"teacher"`
+}
+
+// ParseLesson разбирает описание пары в свободной форме с помощью модели.
+// Незаполненные поля остаются пустыми.
+func (ai *AIService) ParseLesson(ctx context.Context, text string) (domain.Lesson, error) {
+	params := ai.Params()
+	resp, err := ai.client.CreateChatCompletion(ctx, openai.ChatCompletionRequest{
+		Model:       params.Model,
+		Temperature: params.Temperature,
+		MaxTokens:   params.MaxTokens,
+		ResponseFormat: &openai.ChatCompletionResponseFormat{
+			Type: openai.ChatCompletionResponseFormatTypeJSONObject,
+		},
+		Messages: []openai.ChatCompletionMessage{
+			{
+				Role: openai.ChatMessageRoleSystem,
+				Content: "Извлеки из описания учебной пары JSON с полями name, start, end (HH:MM), " +
+					"location, teacher. Преподавателя пиши в именительном падеже. " +
+					"Если поле не указано, оставь пустую строку.",
+			},
+			{
+				Role:    openai.ChatMessageRoleUser,
+				Content: text,
+			},
+		},
+	})
+	ai.observe(AIOperationLessonParse, err, resp.Usage)
+	if err != nil {
+		return domain.Lesson{}, err
+	}
+	if len(resp.Choices) == 0 {
+		return domain.Lesson{}, fmt.Errorf("empty completion")
+	}
+
+	var parsed lessonJSON
+	if err := json.Unmarshal([]byte(resp.Choices[0].Message.Content), &parsed); err != nil {
+		return domain.Lesson{}, fmt.Errorf("decode lesson: %w", err)
+	}
+
+	lesson := domain.Lesson{
+		Name:     strings.TrimSpace(parsed.Name),
+		Location: strings.TrimSpace(parsed.Location),
+		Teacher:  strings.TrimSpace(parsed.Teacher),
+	}
+	if start, err := time.Parse("15:04", parsed.Start); err == nil {
+		lesson.StartTime = start
+		lesson.EndTime = start.Add(DefaultLessonDuration)
+		if end, err := time.Parse("15:04", parsed.End); err == nil {
+			lesson.EndTime = end
+		}
+	}
+
+	return lesson, nil
+}
@@ -0,0 +1,56 @@
+package service
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	openai "github.com/sashabaranov/go-openai"
+)
+
+// newAIServiceWithHandler создает AIService поверх произвольного поддельного API
+func newAIServiceWithHandler(t *testing.T, handler http.HandlerFunc) *AIService {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	cfg := openai.DefaultConfig("test")
+	cfg.BaseURL = server.URL + "/v1"
+	return &AIService{client: openai.NewClientWithConfig(cfg), params: DefaultAIParams}
+}
+
+func TestHandleQuestionEmptyCompletion(t *testing.T) {
+	ai := newAIServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`{"id":"1","object":"chat.completion","choices":[]}`))
+	})
+
+	if _, err := ai.HandleQuestion(context.Background(), "когда физика"); err == nil {
+		t.Fatal("HandleQuestion returned nil error for a reply without choices")
+	}
+}
+
+func TestHandleQuestionCancelled(t *testing.T) {
+	ai := newAIServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		<-r.Context().Done() // Модель "думает", пока клиент не отменит запрос
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		_, err := ai.HandleQuestion(ctx, "когда физика")
+		done <- err
+	}()
+	cancel()
+
+	select {
+	case err := <-done:
+		if err == nil {
+			t.Fatal("cancelled question returned nil error")
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("HandleQuestion ignored context cancellation")
+	}
+}
@@ -0,0 +1,106 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+var (
+	// ErrUnknownBellSchedule - расписание звонков с таким названием не настроено
+	ErrUnknownBellSchedule = errors.New("unknown bell schedule")
+	// ErrUnknownSlot - в расписании звонков пользователя нет пары с таким номером
+	ErrUnknownSlot = errors.New("unknown bell slot")
+)
+
+// ParseBellSchedule собирает расписание звонков из интервалов вида "08:30-10:00".
+// Номера пар соответствуют порядку интервалов, начиная с 1.
+func ParseBellSchedule(name string, slots []string) (domain.BellSchedule, error) {
+	schedule := domain.BellSchedule{Name: name}
+
+	for i, raw := range slots {
+		start, end, ok := findTimeRange(raw)
+		if !ok {
+			return domain.BellSchedule{}, fmt.Errorf("bell schedule %q: invalid slot %d %q", name, i+1, raw)
+		}
+		if !end.After(start) {
+			return domain.BellSchedule{}, fmt.Errorf("bell schedule %q: slot %d ends before it starts", name, i+1)
+		}
+
+		schedule.Slots = append(schedule.Slots, domain.BellSlot{
+			Number:    i + 1,
+			StartTime: start,
+			EndTime:   end,
+		})
+	}
+
+	return schedule, nil
+}
+
+// BellSchedules возвращает все настроенные расписания звонков по алфавиту
+func (s *Service) BellSchedules() []domain.BellSchedule {
+	schedules := make([]domain.BellSchedule, 0, len(s.bells))
+	for _, bs := range s.bells {
+		schedules = append(schedules, bs)
+	}
+	sort.Slice(schedules, func(i, j int) bool {
+		return schedules[i].Name < schedules[j].Name
+	})
+	return schedules
+}
+
+// UserBellSchedule возвращает расписание звонков, выбранное пользователем,
+// или расписание по умолчанию. ok = false, если звонки не настроены.
+func (s *Service) UserBellSchedule(ctx context.Context, userID int64) (domain.BellSchedule, bool, error) {
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return domain.BellSchedule{}, false, err
+	}
+
+	name := settings.BellSchedule
+	if name == "" {
+		name = s.defaultBells
+	}
+
+	bs, ok := s.bells[name]
+	return bs, ok, nil
+}
+
+// SetUserBellSchedule выбирает пользователю расписание звонков
+func (s *Service) SetUserBellSchedule(ctx context.Context, userID int64, name string) error {
+	if _, ok := s.bells[name]; !ok {
+		return ErrUnknownBellSchedule
+	}
+
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	settings.UserID = userID
+	settings.BellSchedule = name
+	return s.repo.SaveSettings(ctx, settings)
+}
+
+// applySlot заполняет время пары по ее номеру в расписании звонков пользователя
+func (s *Service) applySlot(ctx context.Context, userID int64, lesson *domain.Lesson) error {
+	bs, ok, err := s.UserBellSchedule(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrUnknownSlot
+	}
+
+	slot, ok := bs.Slot(lesson.Slot)
+	if !ok {
+		return ErrUnknownSlot
+	}
+
+	lesson.StartTime = slot.StartTime
+	lesson.EndTime = slot.EndTime
+	return nil
+}
@@ -0,0 +1,131 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+func TestParseBellSchedule(t *testing.T) {
+	tests := []struct {
+		name    string
+		slots   []string
+		want    []string // интервалы пар по порядку номеров
+		wantErr bool
+	}{
+		{name: "dash", slots: []string{"08:30-10:00", "10:10-11:40"}, want: []string{"08:30-10:00", "10:10-11:40"}},
+		{name: "spaces and dots", slots: []string{"8.30 - 10.00", "с 12 до 13:30"}, want: []string{"08:30-10:00", "12:00-13:30"}},
+		{name: "empty", slots: nil, want: nil},
+		{name: "garbage", slots: []string{"08:30-10:00", "после обеда"}, wantErr: true},
+		{name: "reversed", slots: []string{"10:00-08:30"}, wantErr: true},
+		{name: "zero length", slots: []string{"10:00-10:00"}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bs, err := ParseBellSchedule("main", tt.slots)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseBellSchedule(%q) = %+v, want an error", tt.slots, bs)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBellSchedule(%q): %v", tt.slots, err)
+			}
+			if bs.Name != "main" || len(bs.Slots) != len(tt.want) {
+				t.Fatalf("ParseBellSchedule(%q) = %+v, want %d slots", tt.slots, bs, len(tt.want))
+			}
+			for i, slot := range bs.Slots {
+				got := slot.StartTime.Format("15:04") + "-" + slot.EndTime.Format("15:04")
+				if got != tt.want[i] || slot.Number != i+1 {
+					t.Errorf("slot %d = %d %s, want %d %s", i, slot.Number, got, i+1, tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestBellScheduleSlots(t *testing.T) {
+	bs, err := ParseBellSchedule("main", []string{"08:30-10:00", "10:10-11:40"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		start string
+		want  int
+	}{
+		{start: "08:30", want: 1},
+		{start: "10:10", want: 2},
+		{start: "09:00", want: 0},
+	}
+	for _, tt := range tests {
+		if got := bs.SlotAt(at(tt.start)); got != tt.want {
+			t.Errorf("SlotAt(%s) = %d, want %d", tt.start, got, tt.want)
+		}
+	}
+
+	if slot, ok := bs.Slot(2); !ok || slot.StartTime.Format("15:04") != "10:10" {
+		t.Errorf("Slot(2) = %+v, %v", slot, ok)
+	}
+	if _, ok := bs.Slot(3); ok {
+		t.Error("Slot(3) found in a two-slot schedule")
+	}
+}
+
+func TestUserBellSchedule(t *testing.T) {
+	ctx := context.Background()
+	main, _ := ParseBellSchedule("main", []string{"08:30-10:00", "10:10-11:40"})
+	college, _ := ParseBellSchedule("college", []string{"09:00-10:30"})
+	svc, _ := newTestService(t, Options{
+		BellSchedules:       map[string]domain.BellSchedule{"main": main, "college": college},
+		DefaultBellSchedule: "main",
+	})
+
+	if names := svc.BellSchedules(); len(names) != 2 || names[0].Name != "college" || names[1].Name != "main" {
+		t.Errorf("BellSchedules() = %+v, want sorted by name", names)
+	}
+
+	bs, ok, err := svc.UserBellSchedule(ctx, 1)
+	if err != nil || !ok || bs.Name != "main" {
+		t.Errorf("UserBellSchedule without a choice = %q, %v, %v; want the default", bs.Name, ok, err)
+	}
+
+	if err := svc.SetUserBellSchedule(ctx, 1, "school"); !errors.Is(err, ErrUnknownBellSchedule) {
+		t.Errorf("SetUserBellSchedule(school) err = %v, want ErrUnknownBellSchedule", err)
+	}
+	if err := svc.SetUserBellSchedule(ctx, 1, "college"); err != nil {
+		t.Fatalf("SetUserBellSchedule: %v", err)
+	}
+	if bs, _, _ := svc.UserBellSchedule(ctx, 1); bs.Name != "college" {
+		t.Errorf("UserBellSchedule = %q, want the selected college", bs.Name)
+	}
+
+	tests := []struct {
+		slot    int
+		want    string
+		wantErr error
+	}{
+		{slot: 1, want: "09:00-10:30"},
+		{slot: 2, wantErr: ErrUnknownSlot},
+	}
+	for _, tt := range tests {
+		lesson := domain.Lesson{Slot: tt.slot}
+		err := svc.applySlot(ctx, 1, &lesson)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("applySlot(%d) err = %v, want %v", tt.slot, err, tt.wantErr)
+			continue
+		}
+		if got := lesson.StartTime.Format("15:04") + "-" + lesson.EndTime.Format("15:04"); err == nil && got != tt.want {
+			t.Errorf("applySlot(%d) = %s, want %s", tt.slot, got, tt.want)
+		}
+	}
+
+	none, _ := newTestService(t, Options{})
+	if _, ok, _ := none.UserBellSchedule(ctx, 1); ok {
+		t.Error("UserBellSchedule ok without configured schedules")
+	}
+}
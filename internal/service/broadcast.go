@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"fmt"
+)
+
+// BroadcastRecipients возвращает ID пользователей, которым можно отправить рассылку
+// (все, кроме заблокировавших бота). Непустой group оставляет только группу -
+// пользователей с этим расписанием звонков, выбранным или по умолчанию.
+func (s *Service) BroadcastRecipients(ctx context.Context, group string) ([]int64, error) {
+	if _, ok := s.bells[group]; group != "" && !ok {
+		return nil, ErrUnknownBellSchedule
+	}
+
+	users, err := s.repo.ListUsers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("list users: %w", err)
+	}
+
+	recipients := make([]int64, 0, len(users))
+	for _, user := range users {
+		if user.Blocked {
+			continue
+		}
+		if group != "" {
+			bs, _, err := s.UserBellSchedule(ctx, user.ID)
+			if err != nil {
+				return nil, fmt.Errorf("get settings: %w", err)
+			}
+			if bs.Name != group {
+				continue
+			}
+		}
+		recipients = append(recipients, user.ID)
+	}
+	return recipients, nil
+}
+
+// MarkUserBlocked отмечает пользователя, заблокировавшего бота. Отметка снимается
+// при следующем апдейте от него.
+func (s *Service) MarkUserBlocked(ctx context.Context, userID int64) error {
+	if err := s.repo.SetUserBlocked(ctx, userID, true); err != nil {
+		return fmt.Errorf("set user blocked: %w", err)
+	}
+	return nil
+}
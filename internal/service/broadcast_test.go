@@ -0,0 +1,60 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+func TestBroadcastRecipients(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService(t, Options{
+		BellSchedules: map[string]domain.BellSchedule{
+			"main":    {Name: "main"},
+			"college": {Name: "college"},
+		},
+		DefaultBellSchedule: "main",
+	})
+
+	for id := int64(1); id <= 4; id++ {
+		if err := svc.TouchUser(ctx, domain.User{ID: id}); err != nil {
+			t.Fatalf("TouchUser: %v", err)
+		}
+	}
+	if err := svc.SetUserBellSchedule(ctx, 2, "college"); err != nil {
+		t.Fatalf("SetUserBellSchedule: %v", err)
+	}
+	if err := svc.SetUserBellSchedule(ctx, 3, "college"); err != nil {
+		t.Fatalf("SetUserBellSchedule: %v", err)
+	}
+	if err := repo.SetUserBlocked(ctx, 3, true); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		group string
+		want  []int64
+	}{
+		{group: "", want: []int64{1, 2, 4}},
+		{group: "college", want: []int64{2}},
+		// Пользователи без выбора получают расписание по умолчанию
+		{group: "main", want: []int64{1, 4}},
+	}
+	for _, tt := range tests {
+		got, err := svc.BroadcastRecipients(ctx, tt.group)
+		if err != nil {
+			t.Fatalf("BroadcastRecipients(%q): %v", tt.group, err)
+		}
+		slices.Sort(got)
+		if !slices.Equal(got, tt.want) {
+			t.Errorf("BroadcastRecipients(%q) = %v, want %v", tt.group, got, tt.want)
+		}
+	}
+
+	if _, err := svc.BroadcastRecipients(ctx, "school"); !errors.Is(err, ErrUnknownBellSchedule) {
+		t.Errorf("unknown group err = %v, want ErrUnknownBellSchedule", err)
+	}
+}
@@ -0,0 +1,42 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/i18n"
+)
+
+// Language возвращает язык интерфейса пользователя: выбранный через /language
+// или определенный по language_code из Telegram
+func (s *Service) Language(ctx context.Context, userID int64, telegramCode string) i18n.Lang {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		s.logger.Errorw("Failed to get user", "error", err, "userID", userID)
+		return i18n.FromCode(telegramCode)
+	}
+
+	if lang, ok := i18n.Parse(user.Language); ok {
+		return lang
+	}
+	return i18n.FromCode(telegramCode)
+}
+
+// SetLanguage сохраняет выбранный пользователем язык интерфейса
+func (s *Service) SetLanguage(ctx context.Context, userID int64, lang i18n.Lang) error {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("get user: %w", err)
+	}
+
+	if user.CreatedAt.IsZero() {
+		user.CreatedAt = time.Now()
+	}
+	user.Language = string(lang)
+
+	if err := s.repo.SaveUser(ctx, user); err != nil {
+		return fmt.Errorf("save user: %w", err)
+	}
+	return nil
+}
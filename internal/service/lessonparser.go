@@ -0,0 +1,425 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+// DefaultLessonDuration используется, если указано только время начала
+const DefaultLessonDuration = 90 * time.Minute
+
+// LessonField - поле пары, которое может отсутствовать во вводе
+type LessonField string
+
+const (
+	FieldName     LessonField = "name"
+	FieldTime     LessonField = "time"
+	FieldLocation LessonField = "location"
+	FieldTeacher  LessonField = "teacher"
+)
+
+// ErrLessonNotRecognized возвращается, если во вводе не нашлось ни одного поля пары
+var ErrLessonNotRecognized = errors.New("lesson not recognized")
+
+// LessonDraft - частично разобранная пара и список полей, которые нужно уточнить
+type LessonDraft struct {
+	Lesson  domain.Lesson
+	Missing []LessonField
+}
+
+// Complete сообщает, что все поля заполнены
+func (d *LessonDraft) Complete() bool {
+	return len(d.Missing) == 0
+}
+
+// Fill заполняет первое недостающее поле ответом пользователя.
+// Для аудитории и преподавателя "-" означает пустое значение.
+func (d *LessonDraft) Fill(text string) error {
+	if d.Complete() {
+		return nil
+	}
+
+	text = strings.TrimSpace(text)
+	field := d.Missing[0]
+
+	switch field {
+	case FieldName:
+		if text == "" {
+			return fmt.Errorf("empty lesson name")
+		}
+		d.Lesson.Name = text
+	case FieldTime:
+		start, end, ok := findTimeRange(text)
+		if !ok {
+			return fmt.Errorf("invalid time %q", text)
+		}
+		d.Lesson.StartTime, d.Lesson.EndTime = start, end
+		d.Lesson.Slot = 0
+	case FieldLocation:
+		if text != "-" {
+			d.Lesson.Location = text
+		}
+	case FieldTeacher:
+		if text != "-" {
+			d.Lesson.Teacher = text
+		}
+	}
+
+	d.Missing = d.Missing[1:]
+	return nil
+}
+
+var (
+	timeRangeRe  = regexp.MustCompile(`(\d{1,2})[:.](\d{2})\s*[-–—]\s*(\d{1,2})[:.](\d{2})`)
+	fromToRe     = regexp.MustCompile(`(?i)(?:^|\s)(?:с|от)\s+(\d{1,2})(?:[:.](\d{2}))?\s+до\s+(\d{1,2})(?:[:.](\d{2}))?(?:\s|$)`)
+	clockRe      = regexp.MustCompile(`(?:^|\s)(?:в\s+|с\s+)?(\d{1,2})(?:[:.](\d{2}))?(?:\s|$)`)
+	roomPrefixRe = regexp.MustCompile(`(?i)(?:^|\s)(?:ауд(?:итория)?|каб(?:инет)?)(?:\.\s*|\s+)([\p{L}\d\-/]+)`)
+	roomNumberRe = regexp.MustCompile(`(?:^|\s)(?:в\s+)?(\d{3,4}[\p{L}]?)(?:\s|$)`)
+	teacherRe    = regexp.MustCompile(`(?:^|\s)(?:у|преп\.?|преподаватель)\s+(\p{Lu}[\p{L}\-]+(?:\s+\p{Lu}\.\s*(?:\p{Lu}\.)?)?)`)
+	weekRe       = regexp.MustCompile(`(?i)(?:^|\s)(?:по\s+)?(числ|знам)[\p{L}]*\.?(?:\s|$)`)
+	slotRe       = regexp.MustCompile(`(?i)(?:^|\s)(\d{1,2})(?:-?я)?\s*пара(?:\s|$)`)
+	spaceRe      = regexp.MustCompile(`\s+`)
+	subjectAlias = map[string]string{
+		"матан":  "Математический анализ",
+		"линал":  "Линейная алгебра",
+		"физра":  "Физическая культура",
+		"инфа":   "Информатика",
+		"англ":   "Английский язык",
+		"дискра": "Дискретная математика",
+		"тервер": "Теория вероятностей",
+	}
+	genitiveSuffix = [][2]string{
+		{"овой", "ова"}, {"евой", "ева"}, {"иной", "ина"},
+		{"ова", "ов"}, {"ева", "ев"}, {"ина", "ин"}, {"ского", "ский"},
+	}
+)
+
+// ParseLessonText разбирает пару без обращения к AI.
+// Поддерживается строгий формат "Название | Начало | Конец | Аудитория | Преподаватель",
+// ввод по номеру пары "2 | Физика | 305 | Петров" и свободный ввод вроде
+// "матан в 9 в 101 у Иванова", "Физика 10:40-12:10 ауд 305", "матан с 9 до 10:30"
+// или "2 пара физика".
+// Слова "числитель"/"знаменатель" ограничивают пару четностью недели.
+// Для пар по номеру заполняется только Lesson.Slot, время подставляет сервис.
+func ParseLessonText(text string) (LessonDraft, error) {
+	text = strings.TrimSpace(text)
+	switch strings.Count(text, "|") {
+	case 4:
+		return parseStrictLesson(text)
+	case 3:
+		if draft, ok := parseSlotLesson(text); ok {
+			return draft, nil
+		}
+	}
+
+	var lesson domain.Lesson
+	rest := " " + text + " "
+	hasTime := false
+
+	if m := weekRe.FindStringSubmatchIndex(rest); m != nil {
+		lesson.Week = 1
+		if strings.EqualFold(rest[m[2]:m[3]], "знам") {
+			lesson.Week = 2
+		}
+		rest = rest[:m[0]] + " " + rest[m[1]:]
+	}
+
+	if m := slotRe.FindStringSubmatchIndex(rest); m != nil {
+		lesson.Slot, _ = strconv.Atoi(rest[m[2]:m[3]])
+		hasTime = true
+		rest = rest[:m[0]] + " " + rest[m[1]:]
+	}
+
+	if m := timeRangeRe.FindStringSubmatchIndex(rest); !hasTime && m != nil {
+		start, err1 := clock(rest[m[2]:m[3]], rest[m[4]:m[5]])
+		end, err2 := clock(rest[m[6]:m[7]], rest[m[8]:m[9]])
+		if err1 == nil && err2 == nil {
+			lesson.StartTime, lesson.EndTime = start, end
+			hasTime = true
+			rest = rest[:m[0]] + " " + rest[m[1]:]
+		}
+	}
+
+	if m := fromToRe.FindStringSubmatchIndex(rest); !hasTime && m != nil {
+		if start, end, ok := fromTo(rest, m); ok {
+			lesson.StartTime, lesson.EndTime = start, end
+			hasTime = true
+			rest = rest[:m[0]] + " " + rest[m[1]:]
+		}
+	}
+
+	if m := teacherRe.FindStringSubmatchIndex(rest); m != nil {
+		lesson.Teacher = nominative(rest[m[2]:m[3]])
+		rest = rest[:m[0]] + " " + rest[m[1]:]
+	}
+
+	if m := roomPrefixRe.FindStringSubmatchIndex(rest); m != nil {
+		lesson.Location = "Ауд. " + rest[m[2]:m[3]]
+		rest = rest[:m[0]] + " " + rest[m[1]:]
+	} else if m := roomNumberRe.FindStringSubmatchIndex(rest); m != nil {
+		lesson.Location = "Ауд. " + rest[m[2]:m[3]]
+		rest = rest[:m[0]] + " " + rest[m[1]:]
+	}
+
+	if !hasTime {
+		if m := clockRe.FindStringSubmatchIndex(rest); m != nil {
+			minutes := "00"
+			if m[4] >= 0 {
+				minutes = rest[m[4]:m[5]]
+			}
+			if start, err := clock(rest[m[2]:m[3]], minutes); err == nil {
+				lesson.StartTime = start
+				lesson.EndTime = start.Add(DefaultLessonDuration)
+				hasTime = true
+				rest = rest[:m[0]] + " " + rest[m[1]:]
+			}
+		}
+	}
+
+	lesson.Name = subjectName(rest)
+
+	draft := LessonDraft{Lesson: lesson}
+	if lesson.Name == "" {
+		draft.Missing = append(draft.Missing, FieldName)
+	}
+	if !hasTime {
+		draft.Missing = append(draft.Missing, FieldTime)
+	}
+	if lesson.Location == "" {
+		draft.Missing = append(draft.Missing, FieldLocation)
+	}
+	if lesson.Teacher == "" {
+		draft.Missing = append(draft.Missing, FieldTeacher)
+	}
+
+	if len(draft.Missing) == 4 {
+		return LessonDraft{}, ErrLessonNotRecognized
+	}
+
+	return draft, nil
+}
+
+// parseStrictLesson разбирает исходный формат из пяти полей через "|"
+func parseStrictLesson(text string) (LessonDraft, error) {
+	parts := strings.Split(text, "|")
+
+	startTime, err := time.Parse("15:04", strings.TrimSpace(parts[1]))
+	if err != nil {
+		return LessonDraft{}, err
+	}
+
+	endTime, err := time.Parse("15:04", strings.TrimSpace(parts[2]))
+	if err != nil {
+		return LessonDraft{}, err
+	}
+
+	return LessonDraft{Lesson: domain.Lesson{
+		Name:      strings.TrimSpace(parts[0]),
+		StartTime: startTime,
+		EndTime:   endTime,
+		Location:  strings.TrimSpace(parts[3]),
+		Teacher:   strings.TrimSpace(parts[4]),
+	}}, nil
+}
+
+// parseSlotLesson разбирает формат "Номер пары | Название | Аудитория | Преподаватель"
+func parseSlotLesson(text string) (LessonDraft, bool) {
+	parts := strings.Split(text, "|")
+
+	slot, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || slot <= 0 {
+		return LessonDraft{}, false
+	}
+
+	return LessonDraft{Lesson: domain.Lesson{
+		Slot:     slot,
+		Name:     strings.TrimSpace(parts[1]),
+		Location: strings.TrimSpace(parts[2]),
+		Teacher:  strings.TrimSpace(parts[3]),
+	}}, true
+}
+
+// findTimeRange ищет в тексте интервал "10:40-12:10" или одиночное время начала
+func findTimeRange(text string) (time.Time, time.Time, bool) {
+	text = " " + strings.TrimSpace(text) + " "
+
+	if m := timeRangeRe.FindStringSubmatch(text); m != nil {
+		start, err1 := clock(m[1], m[2])
+		end, err2 := clock(m[3], m[4])
+		if err1 == nil && err2 == nil {
+			return start, end, true
+		}
+	}
+
+	if m := fromToRe.FindStringSubmatchIndex(text); m != nil {
+		if start, end, ok := fromTo(text, m); ok {
+			return start, end, true
+		}
+	}
+
+	if m := clockRe.FindStringSubmatch(text); m != nil {
+		minutes := m[2]
+		if minutes == "" {
+			minutes = "00"
+		}
+		if start, err := clock(m[1], minutes); err == nil {
+			return start, start.Add(DefaultLessonDuration), true
+		}
+	}
+
+	return time.Time{}, time.Time{}, false
+}
+
+// fromTo собирает интервал из совпадения fromToRe ("с 9 до 10:30"), минуты необязательны
+func fromTo(text string, m []int) (time.Time, time.Time, bool) {
+	group := func(n int) string {
+		if m[2*n] < 0 {
+			return "00"
+		}
+		return text[m[2*n]:m[2*n+1]]
+	}
+
+	start, err1 := clock(group(1), group(2))
+	end, err2 := clock(group(3), group(4))
+	if err1 != nil || err2 != nil {
+		return time.Time{}, time.Time{}, false
+	}
+	return start, end, true
+}
+
+// clock собирает время дня в том же виде, что и time.Parse("15:04", ...)
+func clock(hours, minutes string) (time.Time, error) {
+	h, err := strconv.Atoi(hours)
+	if err != nil || h > 23 {
+		return time.Time{}, fmt.Errorf("invalid hours %q", hours)
+	}
+	m, err := strconv.Atoi(minutes)
+	if err != nil || m > 59 {
+		return time.Time{}, fmt.Errorf("invalid minutes %q", minutes)
+	}
+	return time.Date(0, 1, 1, h, m, 0, 0, time.UTC), nil
+}
+
+// subjectName очищает остаток строки и раскрывает сокращения предметов
+func subjectName(rest string) string {
+	name := strings.TrimSpace(spaceRe.ReplaceAllString(rest, " "))
+	name = strings.Trim(name, " ,.-")
+	for _, trailing := range []string{" в", " с", " у"} {
+		name = strings.TrimSuffix(name, trailing)
+	}
+	if name == "" {
+		return ""
+	}
+
+	if full, ok := subjectAlias[strings.ToLower(name)]; ok {
+		return full
+	}
+
+	runes := []rune(name)
+	runes[0] = unicode.ToUpper(runes[0])
+	return string(runes)
+}
+
+// nominative приводит фамилию из родительного падежа ("у Иванова") к именительному
+func nominative(name string) string {
+	parts := strings.SplitN(name, " ", 2)
+	surname := parts[0]
+	for _, s := range genitiveSuffix {
+		if strings.HasSuffix(surname, s[0]) {
+			surname = strings.TrimSuffix(surname, s[0]) + s[1]
+			break
+		}
+	}
+	if len(parts) == 2 {
+		return surname + " " + strings.TrimSpace(parts[1])
+	}
+	return surname
+}
+
+// ParseLesson разбирает пару из текста пользователя. Если правила не справились
+// с названием или временем и включен AI-разбор, делается попытка через AIService.
+func (s *Service) ParseLesson(ctx context.Context, userID int64, text string) (LessonDraft, error) {
+	draft, err := ParseLessonText(text)
+	if err == nil && draft.Lesson.Slot > 0 {
+		if err := s.applySlot(ctx, userID, &draft.Lesson); err != nil {
+			return LessonDraft{}, err
+		}
+	}
+	if err == nil && !draft.needsAI() {
+		return draft, nil
+	}
+
+	if !s.aiLessonParsing.Load() {
+		return draft, err
+	}
+
+	reservation, limitErr := s.limiter.Allow(ctx, userID, s.UserRole(userID))
+	if limitErr != nil {
+		return draft, err
+	}
+
+	lesson, aiErr := s.ai.ParseLesson(ctx, text)
+	if aiErr != nil {
+		s.logger.Warnw("AI lesson parsing failed", "error", aiErr, "userID", userID)
+		s.releaseQuota(ctx, reservation)
+		return draft, err
+	}
+
+	return draftFromAI(draft.Lesson, lesson), nil
+}
+
+// needsAI - правила не нашли обязательные поля (название или время)
+func (d *LessonDraft) needsAI() bool {
+	for _, f := range d.Missing {
+		if f == FieldName || f == FieldTime {
+			return true
+		}
+	}
+	return false
+}
+
+// draftFromAI дополняет пару, разобранную моделью, тем, что уже нашли правила:
+// четность недели и номер пары модель не извлекает, а пустые поля ответа
+// заполняются найденными правилами значениями
+func draftFromAI(rules, lesson domain.Lesson) LessonDraft {
+	lesson.Week = rules.Week
+	if rules.Slot > 0 {
+		lesson.Slot = rules.Slot
+		lesson.StartTime, lesson.EndTime = rules.StartTime, rules.EndTime
+	}
+	if lesson.Name == "" {
+		lesson.Name = rules.Name
+	}
+	if lesson.StartTime.IsZero() && !rules.StartTime.IsZero() {
+		lesson.StartTime, lesson.EndTime = rules.StartTime, rules.EndTime
+	}
+	if lesson.Location == "" {
+		lesson.Location = rules.Location
+	}
+	if lesson.Teacher == "" {
+		lesson.Teacher = rules.Teacher
+	}
+
+	draft := LessonDraft{Lesson: lesson}
+	if lesson.Name == "" {
+		draft.Missing = append(draft.Missing, FieldName)
+	}
+	if lesson.StartTime.IsZero() {
+		draft.Missing = append(draft.Missing, FieldTime)
+	}
+	if lesson.Location == "" {
+		draft.Missing = append(draft.Missing, FieldLocation)
+	}
+	if lesson.Teacher == "" {
+		draft.Missing = append(draft.Missing, FieldTeacher)
+	}
+	return draft
+}
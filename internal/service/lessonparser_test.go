@@ -0,0 +1,173 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/repository/inmemory"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+func TestParseLessonText(t *testing.T) {
+	tests := []struct {
+		text     string
+		name     string
+		start    string // пусто - время не найдено
+		end      string
+		location string
+		teacher  string
+		week     int
+		slot     int
+		missing  []LessonField
+	}{
+		{
+			text: "Физика | 10:40 | 12:10 | 305 | Петров",
+			name: "Физика", start: "10:40", end: "12:10", location: "305", teacher: "Петров",
+		},
+		{
+			text: "2 | Физика | 305 | Петров",
+			name: "Физика", location: "305", teacher: "Петров", slot: 2,
+		},
+		{
+			text: "Физика 10:40-12:10 ауд 305",
+			name: "Физика", start: "10:40", end: "12:10", location: "Ауд. 305",
+			missing: []LessonField{FieldTeacher},
+		},
+		{
+			text: "Физика 10:40-12:10 ауд.305а",
+			name: "Физика", start: "10:40", end: "12:10", location: "Ауд. 305а",
+			missing: []LessonField{FieldTeacher},
+		},
+		{
+			text: "Информатика в 14 кабинет 12",
+			name: "Информатика", start: "14:00", end: "15:30", location: "Ауд. 12",
+			missing: []LessonField{FieldTeacher},
+		},
+		{
+			text: "матан в 9 в 101 у Иванова",
+			name: "Математический анализ", start: "09:00", end: "10:30", location: "Ауд. 101", teacher: "Иванов",
+		},
+		{
+			text: "Матан с 9 до 10:30",
+			name: "Математический анализ", start: "09:00", end: "10:30",
+			missing: []LessonField{FieldLocation, FieldTeacher},
+		},
+		{
+			text: "Линал от 12.10 до 13.40 преп. Петров",
+			name: "Линейная алгебра", start: "12:10", end: "13:40", teacher: "Петров",
+			missing: []LessonField{FieldLocation},
+		},
+		// Префикс аудитории внутри обычного слова - часть названия
+		{
+			text: "Аудирование 10:00-11:30",
+			name: "Аудирование", start: "10:00", end: "11:30",
+			missing: []LessonField{FieldLocation, FieldTeacher},
+		},
+		{
+			text: "Кабельные сети 10:00-11:30 305",
+			name: "Кабельные сети", start: "10:00", end: "11:30", location: "Ауд. 305",
+			missing: []LessonField{FieldTeacher},
+		},
+		{
+			text: "2 пара физика по знаменателю",
+			name: "Физика", slot: 2, week: 2,
+			missing: []LessonField{FieldLocation, FieldTeacher},
+		},
+		{
+			text: "числитель 8:30-10:00 история",
+			name: "История", start: "08:30", end: "10:00", week: 1,
+			missing: []LessonField{FieldLocation, FieldTeacher},
+		},
+		{
+			text:     "305",
+			location: "Ауд. 305",
+			missing:  []LessonField{FieldName, FieldTime, FieldTeacher},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.text, func(t *testing.T) {
+			draft, err := ParseLessonText(tt.text)
+			if err != nil {
+				t.Fatalf("ParseLessonText: %v", err)
+			}
+
+			l := draft.Lesson
+			start, end := "", ""
+			if !l.StartTime.IsZero() {
+				start, end = l.StartTime.Format("15:04"), l.EndTime.Format("15:04")
+			}
+			got := []interface{}{l.Name, start, end, l.Location, l.Teacher, l.Week, l.Slot}
+			want := []interface{}{tt.name, tt.start, tt.end, tt.location, tt.teacher, tt.week, tt.slot}
+			if !reflect.DeepEqual(got, want) {
+				t.Errorf("lesson = %v, want %v", got, want)
+			}
+			if !reflect.DeepEqual(draft.Missing, tt.missing) {
+				t.Errorf("missing = %v, want %v", draft.Missing, tt.missing)
+			}
+		})
+	}
+}
+
+func TestParseLessonTextNotRecognized(t *testing.T) {
+	for _, text := range []string{"", "   "} {
+		if _, err := ParseLessonText(text); !errors.Is(err, ErrLessonNotRecognized) {
+			t.Errorf("ParseLessonText(%q) err = %v, want ErrLessonNotRecognized", text, err)
+		}
+	}
+}
+
+func TestLessonDraftFillTime(t *testing.T) {
+	for text, want := range map[string]string{
+		"10:40-12:10": "10:40-12:10",
+		"с 9 до 10":   "09:00-10:00",
+		"в 14":        "14:00-15:30",
+	} {
+		draft := LessonDraft{Missing: []LessonField{FieldTime}}
+		if err := draft.Fill(text); err != nil {
+			t.Errorf("Fill(%q): %v", text, err)
+			continue
+		}
+		got := draft.Lesson.StartTime.Format("15:04") + "-" + draft.Lesson.EndTime.Format("15:04")
+		if got != want || !draft.Complete() {
+			t.Errorf("Fill(%q) = %s, want %s", text, got, want)
+		}
+	}
+}
+
+func TestParseLessonAIKeepsRuleFields(t *testing.T) {
+	ctx := context.Background()
+	ai, calls := newFakeOpenAI(t, `{\"name\":\"Физика\",\"start\":\"15:00\",\"end\":\"16:30\",\"location\":\"\",\"teacher\":\"Петров\"}`, nil)
+	bells, err := ParseBellSchedule("main", []string{"08:30-10:00", "10:10-11:40"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	svc := New(inmemory.New(), ai, logger.New("error"), Options{
+		AILessonParsing:     true,
+		BellSchedules:       map[string]domain.BellSchedule{"main": bells},
+		DefaultBellSchedule: "main",
+	})
+
+	// Правила нашли номер пары, четность и аудиторию, но не название
+	draft, err := svc.ParseLesson(ctx, 1, "2 пара по числителю ауд 305")
+	if err != nil {
+		t.Fatalf("ParseLesson: %v", err)
+	}
+	if calls.Load() != 1 {
+		t.Fatalf("AI called %d times, want 1", calls.Load())
+	}
+
+	l := draft.Lesson
+	if l.Name != "Физика" || l.Teacher != "Петров" {
+		t.Errorf("AI fields = %q, %q", l.Name, l.Teacher)
+	}
+	if l.Slot != 2 || l.Week != 1 || l.StartTime.Format("15:04") != "10:10" || l.Location != "Ауд. 305" {
+		t.Errorf("lesson = %+v, want slot 2 at 10:10 on numerator weeks in Ауд. 305", l)
+	}
+	if !draft.Complete() {
+		t.Errorf("missing = %v", draft.Missing)
+	}
+}
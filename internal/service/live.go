@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+// ErrUnknownTimeZone - часовой пояс не найден в базе IANA
+var ErrUnknownTimeZone = errors.New("unknown time zone")
+
+// WeekParity возвращает четность учебной недели: 1 - числитель, 2 - знаменатель
+func WeekParity(t time.Time) int {
+	_, week := t.ISOWeek()
+	if week%2 == 0 {
+		return 2 // Знаменатель
+	}
+	return 1 // Числитель
+}
+
+// LiveStatus - текущая и следующая пара пользователя
+type LiveStatus struct {
+	Now     time.Time
+	Parity  int
+	Current *domain.Lesson
+	// CurrentEnds - время окончания текущей пары
+	CurrentEnds time.Time
+	Next        *domain.Lesson
+	// NextStarts - время начала следующей пары (может быть в другой день)
+	NextStarts time.Time
+}
+
+// Location возвращает часовой пояс пользователя
+func (s *Service) Location(ctx context.Context, userID int64) *time.Location {
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if err != nil || settings.TimeZone == "" {
+		return s.location
+	}
+
+	loc, err := time.LoadLocation(settings.TimeZone)
+	if err != nil {
+		return s.location
+	}
+	return loc
+}
+
+// Now возвращает текущее время в часовом поясе пользователя
+func (s *Service) Now(ctx context.Context, userID int64) time.Time {
+	return s.now().In(s.Location(ctx, userID))
+}
+
+// SetTimeZone сохраняет часовой пояс пользователя
+func (s *Service) SetTimeZone(ctx context.Context, userID int64, name string) error {
+	if _, err := time.LoadLocation(name); err != nil || name == "" {
+		return ErrUnknownTimeZone
+	}
+
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return err
+	}
+
+	settings.UserID = userID
+	settings.TimeZone = name
+	return s.repo.SaveSettings(ctx, settings)
+}
+
+// Holiday возвращает название праздника, если дата выходная
+func (s *Service) Holiday(date time.Time) (string, bool) {
+	name, ok := s.holidays[date.Format("2006-01-02")]
+	return name, ok
+}
+
+// LessonsOn возвращает пары на конкретную дату с учетом четности недели.
+// В праздничные дни пар нет.
+func (s *Service) LessonsOn(ctx context.Context, userID int64, date time.Time) ([]domain.Lesson, error) {
+	if _, ok := s.Holiday(date); ok {
+		return nil, nil
+	}
+
+	lessons, err := s.GetSchedule(ctx, userID, date.Weekday())
+	if errors.Is(err, ErrScheduleNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	parity := WeekParity(date)
+	filtered := lessons[:0]
+	for _, lesson := range lessons {
+		if lesson.Week == 0 || lesson.Week == parity {
+			filtered = append(filtered, lesson)
+		}
+	}
+	return filtered, nil
+}
+
+// nextLessonDays - на сколько дней вперед ищется следующая пара. Двух недель хватает,
+// чтобы найти пару, которая идет только по числителю или только по знаменателю.
+const nextLessonDays = 14
+
+// GetLiveStatus определяет, какая пара идет сейчас и какая будет следующей.
+// Следующая пара ищется в пределах двух недель.
+func (s *Service) GetLiveStatus(ctx context.Context, userID int64) (LiveStatus, error) {
+	now := s.Now(ctx, userID)
+	status := LiveStatus{Now: now, Parity: WeekParity(now)}
+
+	for offset := 0; offset <= nextLessonDays && status.Next == nil; offset++ {
+		date := now.AddDate(0, 0, offset)
+		lessons, err := s.LessonsOn(ctx, userID, date)
+		if err != nil {
+			return LiveStatus{}, err
+		}
+
+		for i := range lessons {
+			lesson := lessons[i]
+			start, end := lesson.StartOn(date), lesson.EndOn(date)
+
+			if offset == 0 && !now.Before(start) && now.Before(end) {
+				status.Current = &lesson
+				status.CurrentEnds = end
+				continue
+			}
+
+			if start.After(now) {
+				status.Next = &lesson
+				status.NextStarts = start
+				break
+			}
+		}
+	}
+
+	return status, nil
+}
@@ -0,0 +1,46 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+func TestGetLiveStatusFindsNextLessonTwoWeeksAhead(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t, Options{})
+	// Понедельник 43-й недели (числитель), пары этого дня уже закончились
+	svc.now = func() time.Time { return time.Date(2025, 10, 20, 20, 0, 0, 0, time.UTC) }
+
+	numerator := lesson("Математика", "09:00", "10:30")
+	numerator.Week = 1
+	if _, err := svc.SaveSchedule(ctx, 1, time.Monday, []domain.Lesson{numerator}); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := svc.GetLiveStatus(ctx, 1)
+	if err != nil {
+		t.Fatalf("GetLiveStatus: %v", err)
+	}
+	want := time.Date(2025, 11, 3, 9, 0, 0, 0, time.UTC)
+	if status.Next == nil || !status.NextStarts.Equal(want) {
+		t.Fatalf("next = %v at %v, want Математика at %v", status.Next, status.NextStarts, want)
+	}
+	if status.Current != nil {
+		t.Errorf("current = %v, want none", status.Current)
+	}
+}
+
+func TestGetLiveStatusNoLessons(t *testing.T) {
+	svc, _ := newTestService(t, Options{})
+
+	status, err := svc.GetLiveStatus(context.Background(), 1)
+	if err != nil {
+		t.Fatalf("GetLiveStatus: %v", err)
+	}
+	if status.Current != nil || status.Next != nil {
+		t.Errorf("status = %+v, want no lessons", status)
+	}
+}
@@ -0,0 +1,50 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+// Profile - сохраненные данные пользователя для /profile
+type Profile struct {
+	User         domain.User
+	Role         domain.Role
+	TimeZone     string
+	BellSchedule string // пусто, если расписания звонков не настроены
+}
+
+// TouchUser сохраняет данные пользователя из Telegram и время последней активности.
+// Пользователь создается при первом обращении.
+func (s *Service) TouchUser(ctx context.Context, profile domain.User) error {
+	profile.LastSeen = time.Now()
+	if err := s.repo.UpsertUserProfile(ctx, profile); err != nil {
+		return fmt.Errorf("upsert user: %w", err)
+	}
+	return nil
+}
+
+// Profile возвращает профиль пользователя с его настройками
+func (s *Service) Profile(ctx context.Context, userID int64) (Profile, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return Profile{}, fmt.Errorf("get user: %w", err)
+	}
+
+	bells, ok, err := s.UserBellSchedule(ctx, userID)
+	if err != nil {
+		return Profile{}, fmt.Errorf("get bell schedule: %w", err)
+	}
+
+	profile := Profile{
+		User:     user,
+		Role:     s.UserRole(userID),
+		TimeZone: s.Location(ctx, userID).String(),
+	}
+	if ok {
+		profile.BellSchedule = bells.Name
+	}
+	return profile, nil
+}
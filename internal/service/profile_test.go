@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+func TestTouchUser(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService(t, Options{Admins: []int64{1}})
+
+	if err := svc.TouchUser(ctx, domain.User{ID: 1, Username: "old", FirstName: "Анна", LanguageCode: "ru"}); err != nil {
+		t.Fatalf("TouchUser: %v", err)
+	}
+	first, _ := repo.GetUser(ctx, 1)
+	if first.CreatedAt.IsZero() || first.Username != "old" {
+		t.Fatalf("user was not registered: %+v", first)
+	}
+
+	if err := repo.SetUserBlocked(ctx, 1, true); err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(time.Millisecond)
+	if err := svc.TouchUser(ctx, domain.User{ID: 1, Username: "new", FirstName: "Анна", LanguageCode: "en"}); err != nil {
+		t.Fatalf("TouchUser: %v", err)
+	}
+
+	user, _ := repo.GetUser(ctx, 1)
+	if user.Username != "new" || user.LanguageCode != "en" {
+		t.Errorf("profile was not updated: %+v", user)
+	}
+	if !user.CreatedAt.Equal(first.CreatedAt) {
+		t.Errorf("created_at changed from %s to %s", first.CreatedAt, user.CreatedAt)
+	}
+	if !user.LastSeen.After(first.LastSeen) {
+		t.Errorf("last_seen was not advanced")
+	}
+	if user.Blocked {
+		t.Error("a new update did not clear the blocked flag")
+	}
+
+	profile, err := svc.Profile(ctx, 1)
+	if err != nil {
+		t.Fatalf("Profile: %v", err)
+	}
+	if profile.User.Username != "new" || profile.Role != domain.RoleAdmin || profile.TimeZone != "UTC" {
+		t.Errorf("profile = %+v", profile)
+	}
+}
@@ -0,0 +1,248 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+// Limits описывает ограничения на AI-запросы для одной роли.
+// Нулевое значение поля означает отсутствие ограничения.
+type Limits struct {
+	RatePerMinute int
+	Burst         int
+	Daily         int
+	Monthly       int
+}
+
+// DefaultAILimits используются, если для роли ничего не настроено
+var DefaultAILimits = map[domain.Role]Limits{
+	domain.RoleUser:  {RatePerMinute: 5, Burst: 3, Daily: 30, Monthly: 300},
+	domain.RoleAdmin: {RatePerMinute: 30, Burst: 10},
+}
+
+// LimitReason причина отказа в AI-запросе
+type LimitReason string
+
+const (
+	LimitRate    LimitReason = "rate"
+	LimitDaily   LimitReason = "daily"
+	LimitMonthly LimitReason = "monthly"
+)
+
+// LimitError возвращается, когда пользователь превысил лимит
+type LimitError struct {
+	Reason     LimitReason
+	RetryAfter time.Duration
+}
+
+func (e *LimitError) Error() string {
+	return fmt.Sprintf("ai limit exceeded: %s, retry after %s", e.Reason, e.RetryAfter)
+}
+
+// Usage содержит текущие счетчики пользователя вместе с его лимитами
+type Usage struct {
+	Role   domain.Role
+	Limits Limits
+	Day    int
+	Month  int
+}
+
+// tokenBucket - простой token bucket для одного пользователя
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimiter ограничивает частоту и количество AI-запросов
+type RateLimiter struct {
+	mu      sync.Mutex
+	repo    domain.UsageRepository
+	buckets map[int64]*tokenBucket
+	now     func() time.Time
+
+	// limitsMu отдельно от mu: лимиты читаются внутри Allow под mu
+	limitsMu sync.RWMutex
+	limits   map[domain.Role]Limits
+}
+
+func NewRateLimiter(repo domain.UsageRepository, limits map[domain.Role]Limits) *RateLimiter {
+	rl := &RateLimiter{
+		repo:    repo,
+		buckets: make(map[int64]*tokenBucket),
+		now:     time.Now,
+	}
+	rl.SetLimits(limits)
+	return rl
+}
+
+// SetLimits заменяет лимиты. Роли, для которых лимиты не заданы, получают DefaultAILimits.
+// Накопленные токены и счетчики квот сохраняются.
+func (rl *RateLimiter) SetLimits(limits map[domain.Role]Limits) {
+	merged := make(map[domain.Role]Limits, len(DefaultAILimits))
+	for role, l := range DefaultAILimits {
+		merged[role] = l
+	}
+	for role, l := range limits {
+		merged[role] = l
+	}
+
+	rl.limitsMu.Lock()
+	defer rl.limitsMu.Unlock()
+	rl.limits = merged
+}
+
+// Forget удаляет накопленное состояние token bucket пользователя
+func (rl *RateLimiter) Forget(userID int64) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+	delete(rl.buckets, userID)
+}
+
+// LimitsFor возвращает лимиты для роли (для неизвестной роли - лимиты обычного пользователя)
+func (rl *RateLimiter) LimitsFor(role domain.Role) Limits {
+	rl.limitsMu.RLock()
+	defer rl.limitsMu.RUnlock()
+	if l, ok := rl.limits[role]; ok {
+		return l
+	}
+	return rl.limits[domain.RoleUser]
+}
+
+// Reservation - место в дневной и месячной квоте, занятое вызовом Allow
+type Reservation struct {
+	rl     *RateLimiter
+	userID int64
+	day    string
+	month  string
+}
+
+// Allow проверяет квоты и token bucket, списывает токен и сразу занимает место в квотах,
+// чтобы одновременные запросы не превысили Daily и Monthly.
+// Если AI-запрос не удался, место нужно вернуть через Reservation.Release.
+func (rl *RateLimiter) Allow(ctx context.Context, userID int64, role domain.Role) (*Reservation, error) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	limits := rl.LimitsFor(role)
+	now := rl.now()
+
+	usage, err := rl.currentUsage(ctx, userID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	if limits.Daily > 0 && usage.DayCount >= limits.Daily {
+		tomorrow := time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, now.Location())
+		return nil, &LimitError{Reason: LimitDaily, RetryAfter: tomorrow.Sub(now)}
+	}
+	if limits.Monthly > 0 && usage.MonthCount >= limits.Monthly {
+		nextMonth := time.Date(now.Year(), now.Month()+1, 1, 0, 0, 0, 0, now.Location())
+		return nil, &LimitError{Reason: LimitMonthly, RetryAfter: nextMonth.Sub(now)}
+	}
+
+	if limits.RatePerMinute > 0 {
+		if wait, ok := rl.takeToken(userID, limits, now); !ok {
+			return nil, &LimitError{Reason: LimitRate, RetryAfter: wait}
+		}
+	}
+
+	usage.DayCount++
+	usage.MonthCount++
+	if err := rl.repo.SaveAIUsage(ctx, usage); err != nil {
+		return nil, err
+	}
+
+	return &Reservation{rl: rl, userID: userID, day: usage.Day, month: usage.Month}, nil
+}
+
+// takeToken списывает токен из bucket пользователя. Если токенов нет, возвращает время ожидания.
+// Вызывается под rl.mu.
+func (rl *RateLimiter) takeToken(userID int64, limits Limits, now time.Time) (time.Duration, bool) {
+	burst := float64(limits.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	perSecond := float64(limits.RatePerMinute) / 60
+
+	bucket, ok := rl.buckets[userID]
+	if !ok {
+		bucket = &tokenBucket{tokens: burst, last: now}
+		rl.buckets[userID] = bucket
+	}
+
+	bucket.tokens = math.Min(burst, bucket.tokens+now.Sub(bucket.last).Seconds()*perSecond)
+	bucket.last = now
+
+	if bucket.tokens < 1 {
+		return time.Duration((1 - bucket.tokens) / perSecond * float64(time.Second)), false
+	}
+	bucket.tokens--
+	return 0, true
+}
+
+// Release возвращает место в квотах после неудачного AI-запроса.
+// Токен частоты не возвращается: запрос к API все равно был сделан.
+// Если день или месяц уже сменился, соответствующий счетчик не трогается.
+func (r *Reservation) Release(ctx context.Context) error {
+	rl := r.rl
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	usage, err := rl.currentUsage(ctx, r.userID, rl.now())
+	if err != nil {
+		return err
+	}
+
+	if usage.Day == r.day && usage.DayCount > 0 {
+		usage.DayCount--
+	}
+	if usage.Month == r.month && usage.MonthCount > 0 {
+		usage.MonthCount--
+	}
+	return rl.repo.SaveAIUsage(ctx, usage)
+}
+
+// Usage возвращает текущие счетчики пользователя
+func (rl *RateLimiter) Usage(ctx context.Context, userID int64, role domain.Role) (Usage, error) {
+	rl.mu.Lock()
+	defer rl.mu.Unlock()
+
+	usage, err := rl.currentUsage(ctx, userID, rl.now())
+	if err != nil {
+		return Usage{}, err
+	}
+
+	return Usage{
+		Role:   role,
+		Limits: rl.LimitsFor(role),
+		Day:    usage.DayCount,
+		Month:  usage.MonthCount,
+	}, nil
+}
+
+// currentUsage загружает счетчики и сбрасывает их при смене дня или месяца
+func (rl *RateLimiter) currentUsage(ctx context.Context, userID int64, now time.Time) (domain.AIUsage, error) {
+	usage, err := rl.repo.GetAIUsage(ctx, userID)
+	if err != nil {
+		return domain.AIUsage{}, err
+	}
+	usage.UserID = userID
+
+	day := now.Format("2006-01-02")
+	month := now.Format("2006-01")
+	if usage.Day != day {
+		usage.Day = day
+		usage.DayCount = 0
+	}
+	if usage.Month != month {
+		usage.Month = month
+		usage.MonthCount = 0
+	}
+
+	return usage, nil
+}
@@ -0,0 +1,171 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/repository/inmemory"
+)
+
+func newTestLimiter(limits Limits) (*RateLimiter, *time.Time) {
+	now := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	rl := NewRateLimiter(inmemory.New(), map[domain.Role]Limits{domain.RoleUser: limits})
+	rl.now = func() time.Time { return now }
+	return rl, &now
+}
+
+func limitReason(err error) LimitReason {
+	var limitErr *LimitError
+	if errors.As(err, &limitErr) {
+		return limitErr.Reason
+	}
+	return ""
+}
+
+func TestRateLimiterQuotas(t *testing.T) {
+	ctx := context.Background()
+	rl, now := newTestLimiter(Limits{Daily: 2, Monthly: 3})
+
+	for i := 0; i < 2; i++ {
+		if _, err := rl.Allow(ctx, 1, domain.RoleUser); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+	if _, err := rl.Allow(ctx, 1, domain.RoleUser); limitReason(err) != LimitDaily {
+		t.Fatalf("third request err = %v, want daily limit", err)
+	}
+
+	*now = now.AddDate(0, 0, 1)
+	if _, err := rl.Allow(ctx, 1, domain.RoleUser); err != nil {
+		t.Fatalf("next day: %v", err)
+	}
+	if _, err := rl.Allow(ctx, 1, domain.RoleUser); limitReason(err) != LimitMonthly {
+		t.Fatalf("err = %v, want monthly limit", err)
+	}
+
+	usage, err := rl.Usage(ctx, 1, domain.RoleUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Day != 1 || usage.Month != 3 {
+		t.Errorf("usage = day %d, month %d; want 1, 3", usage.Day, usage.Month)
+	}
+}
+
+func TestRateLimiterRate(t *testing.T) {
+	ctx := context.Background()
+	rl, now := newTestLimiter(Limits{RatePerMinute: 6, Burst: 2})
+
+	for i := 0; i < 2; i++ {
+		if _, err := rl.Allow(ctx, 1, domain.RoleUser); err != nil {
+			t.Fatalf("request %d: %v", i+1, err)
+		}
+	}
+	_, err := rl.Allow(ctx, 1, domain.RoleUser)
+	var limitErr *LimitError
+	if !errors.As(err, &limitErr) || limitErr.Reason != LimitRate || limitErr.RetryAfter != 10*time.Second {
+		t.Fatalf("err = %v, want rate limit with 10s wait", err)
+	}
+
+	// Отказ по частоте не занимает место в квотах
+	if usage, _ := rl.Usage(ctx, 1, domain.RoleUser); usage.Day != 2 {
+		t.Errorf("day usage = %d, want 2", usage.Day)
+	}
+
+	*now = now.Add(10 * time.Second)
+	if _, err := rl.Allow(ctx, 1, domain.RoleUser); err != nil {
+		t.Fatalf("after refill: %v", err)
+	}
+}
+
+func TestRateLimiterConcurrentRequestsRespectQuota(t *testing.T) {
+	ctx := context.Background()
+	rl, _ := newTestLimiter(Limits{Daily: 5})
+
+	var (
+		wg      sync.WaitGroup
+		mu      sync.Mutex
+		allowed int
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := rl.Allow(ctx, 1, domain.RoleUser); err == nil {
+				mu.Lock()
+				allowed++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if allowed != 5 {
+		t.Errorf("allowed %d concurrent requests, want exactly the daily quota of 5", allowed)
+	}
+}
+
+func TestReservationRelease(t *testing.T) {
+	ctx := context.Background()
+	rl, now := newTestLimiter(Limits{Daily: 1, Monthly: 10})
+
+	reservation, err := rl.Allow(ctx, 1, domain.RoleUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := rl.Allow(ctx, 1, domain.RoleUser); limitReason(err) != LimitDaily {
+		t.Fatalf("err = %v, the reservation must count against the quota", err)
+	}
+
+	if err := reservation.Release(ctx); err != nil {
+		t.Fatalf("Release: %v", err)
+	}
+	if usage, _ := rl.Usage(ctx, 1, domain.RoleUser); usage.Day != 0 || usage.Month != 0 {
+		t.Fatalf("usage after release = day %d, month %d; want 0, 0", usage.Day, usage.Month)
+	}
+
+	// Место, занятое вчера, не освобождает квоту сегодняшнего дня
+	reservation, err = rl.Allow(ctx, 1, domain.RoleUser)
+	if err != nil {
+		t.Fatal(err)
+	}
+	*now = now.AddDate(0, 0, 1)
+	if _, err := rl.Allow(ctx, 1, domain.RoleUser); err != nil {
+		t.Fatal(err)
+	}
+	if err := reservation.Release(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if usage, _ := rl.Usage(ctx, 1, domain.RoleUser); usage.Day != 1 || usage.Month != 1 {
+		t.Errorf("usage = day %d, month %d; want 1, 1", usage.Day, usage.Month)
+	}
+}
+
+func TestTranscribeVoiceReleasesQuotaOnFailure(t *testing.T) {
+	ctx := context.Background()
+	transcriber := &fakeTranscriber{Err: errors.New("whisper unavailable")}
+	svc, _ := newTestService(t, Options{
+		Transcriber: transcriber,
+		AILimits:    map[domain.Role]Limits{domain.RoleUser: {Daily: 1}},
+	})
+
+	if _, err := svc.TranscribeVoice(ctx, 1, strings.NewReader("ogg"), "voice.ogg", "ru"); err == nil {
+		t.Fatal("TranscribeVoice returned nil error")
+	}
+	if usage, _ := svc.GetAIUsage(ctx, 1); usage.Day != 0 {
+		t.Fatalf("failed transcription used %d of the daily quota", usage.Day)
+	}
+
+	transcriber.Err, transcriber.Text = nil, "когда физика"
+	if _, err := svc.TranscribeVoice(ctx, 1, strings.NewReader("ogg"), "voice.ogg", "ru"); err != nil {
+		t.Fatalf("TranscribeVoice: %v", err)
+	}
+	if usage, _ := svc.GetAIUsage(ctx, 1); usage.Day != 1 {
+		t.Fatalf("successful transcription used %d of the daily quota, want 1", usage.Day)
+	}
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+// DefaultTransferTime - минимальный перерыв для перехода между корпусами
+const DefaultTransferTime = 15 * time.Minute
+
+// roomPrefixes - слова, которыми пользователи обозначают аудиторию. После слова
+// идет точка, пробел или сразу цифра, чтобы не резать слова вроде "аудиторный".
+var roomPrefixes = regexp.MustCompile(`(?i)^(?:ауд(?:итория)?|каб(?:инет)?|room)(?:\.\s*|\s+|(\d))`)
+
+// TransferWarning - соседние пары в разных корпусах со слишком коротким перерывом
+type TransferWarning struct {
+	From  domain.Lesson
+	To    domain.Lesson
+	Break time.Duration
+}
+
+// Campus - справочник корпусов и аудиторий
+type Campus struct {
+	Rooms map[string]domain.Room
+	// TransferTime - минимальный перерыв для перехода между корпусами
+	TransferTime time.Duration
+}
+
+// NormalizeRoomCode приводит "Ауд. 305" или "каб 305" к коду "305"
+func NormalizeRoomCode(location string) string {
+	code := roomPrefixes.ReplaceAllString(strings.TrimSpace(location), "${1}")
+	return strings.ToLower(strings.TrimSpace(code))
+}
+
+// FindRoom ищет аудиторию по коду или тексту из поля Location
+func (s *Service) FindRoom(location string) (domain.Room, bool) {
+	room, ok := s.campus.Rooms[NormalizeRoomCode(location)]
+	return room, ok
+}
+
+// TransferWarnings находит соседние пары в разных корпусах,
+// между которыми перерыв меньше времени на переход
+func (s *Service) TransferWarnings(lessons []domain.Lesson) []TransferWarning {
+	transfer := s.campus.TransferTime
+	if transfer <= 0 {
+		transfer = DefaultTransferTime
+	}
+
+	sorted := append([]domain.Lesson(nil), lessons...)
+	SortLessons(sorted)
+
+	var warnings []TransferWarning
+	for i := 0; i+1 < len(sorted); i++ {
+		from, okFrom := s.FindRoom(sorted[i].Location)
+		to, okTo := s.FindRoom(sorted[i+1].Location)
+		if !okFrom || !okTo || from.Building.Code == to.Building.Code {
+			continue
+		}
+
+		gap := sorted[i+1].StartTime.Sub(sorted[i].EndTime)
+		if gap < transfer {
+			warnings = append(warnings, TransferWarning{From: sorted[i], To: sorted[i+1], Break: gap})
+		}
+	}
+
+	return warnings
+}
@@ -0,0 +1,99 @@
+package service
+
+import (
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+func TestNormalizeRoomCode(t *testing.T) {
+	tests := map[string]string{
+		"305":             "305",
+		" Ауд. 305 ":      "305",
+		"ауд 305":         "305",
+		"аудитория А-101": "а-101",
+		"каб.12":          "12",
+		"Кабинет 7":       "7",
+		"Room 1.2":        "1.2",
+		"ауд305":          "305",
+		"room101":         "101",
+		"Аудиторный фонд": "аудиторный фонд",
+		"":                "",
+	}
+	for location, want := range tests {
+		if got := NormalizeRoomCode(location); got != want {
+			t.Errorf("NormalizeRoomCode(%q) = %q, want %q", location, got, want)
+		}
+	}
+}
+
+func TestTransferWarnings(t *testing.T) {
+	main := domain.Building{Code: "A", Name: "Главный корпус"}
+	lab := domain.Building{Code: "B", Name: "Лабораторный корпус"}
+	svc, _ := newTestService(t, Options{Campus: Campus{
+		Rooms: map[string]domain.Room{
+			"101": {Code: "101", Building: main},
+			"102": {Code: "102", Building: main},
+			"201": {Code: "201", Building: lab},
+		},
+		TransferTime: 20 * time.Minute,
+	}})
+
+	at := func(name, start, end, location string) domain.Lesson {
+		l := lesson(name, start, end)
+		l.Location = location
+		return l
+	}
+
+	tests := []struct {
+		name    string
+		lessons []domain.Lesson
+		want    []time.Duration // перерывы в найденных предупреждениях
+	}{
+		{
+			name:    "short break between buildings",
+			lessons: []domain.Lesson{at("Физика", "08:30", "10:00", "ауд. 101"), at("Химия", "10:10", "11:40", "201")},
+			want:    []time.Duration{10 * time.Minute},
+		},
+		{
+			name:    "enough time",
+			lessons: []domain.Lesson{at("Физика", "08:30", "10:00", "101"), at("Химия", "10:30", "12:00", "201")},
+		},
+		{
+			name:    "same building",
+			lessons: []domain.Lesson{at("Физика", "08:30", "10:00", "101"), at("Химия", "10:05", "11:35", "102")},
+		},
+		{
+			name:    "unknown room",
+			lessons: []domain.Lesson{at("Физика", "08:30", "10:00", "101"), at("Химия", "10:05", "11:35", "онлайн")},
+		},
+		{
+			name: "unsorted input",
+			lessons: []domain.Lesson{
+				at("Химия", "10:10", "11:40", "201"),
+				at("История", "11:55", "13:25", "101"),
+				at("Физика", "08:30", "10:00", "101"),
+			},
+			want: []time.Duration{10 * time.Minute, 15 * time.Minute},
+		},
+		{
+			name:    "break equal to transfer time",
+			lessons: []domain.Lesson{at("Физика", "08:30", "10:00", "101"), at("Химия", "10:20", "11:50", "201")},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			warnings := svc.TransferWarnings(tt.lessons)
+			if len(warnings) != len(tt.want) {
+				t.Fatalf("got %d warnings %+v, want %d", len(warnings), warnings, len(tt.want))
+			}
+			for i, w := range warnings {
+				if w.Break != tt.want[i] {
+					t.Errorf("warning %d break = %s, want %s", i, w.Break, tt.want[i])
+				}
+			}
+		})
+	}
+}
@@ -2,31 +2,115 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
+	"io"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/polyk005/tg_bot/internal/domain"
 	"github.com/polyk005/tg_bot/pkg/logger"
 )
 
+// Options содержит настройки сервиса, не относящиеся к хранилищу
+type Options struct {
+	Admins   []int64
+	AILimits map[domain.Role]Limits
+	// Transcriber по умолчанию - Whisper через AIService
+	Transcriber Transcriber
+	// AILessonParsing включает разбор пар через AI, если правила не справились
+	AILessonParsing bool
+	// BellSchedules - расписания звонков по названиям
+	BellSchedules map[string]domain.BellSchedule
+	// DefaultBellSchedule используется, если пользователь не выбрал свое
+	DefaultBellSchedule string
+	// Location - часовой пояс по умолчанию (time.Local, если не задан)
+	Location *time.Location
+	// Holidays - праздничные дни без пар: дата (2006-01-02) -> название
+	Holidays map[string]string
+	// Campus - корпуса и аудитории
+	Campus Campus
+}
+
 type Service struct {
 	repo            domain.Repository
 	ai              *AIService
+	transcriber     Transcriber
+	limiter         *RateLimiter
 	logger          logger.Logger
+	admins          map[int64]struct{}
+	aiLessonParsing atomic.Bool
+	bells           map[string]domain.BellSchedule
+	defaultBells    string
+	location        *time.Location
+	holidays        map[string]string
+	campus          Campus
 	schedules       map[int64]map[time.Weekday][]domain.Lesson
 	weeklySchedules map[int64]string // Хранит URL фотографий недельных расписаний
 	mu              sync.RWMutex
+	now             func() time.Time
 }
 
-func New(repo domain.Repository, ai *AIService, log logger.Logger) *Service {
-	return &Service{
+func New(repo domain.Repository, ai *AIService, log logger.Logger, opts Options) *Service {
+	admins := make(map[int64]struct{}, len(opts.Admins))
+	for _, id := range opts.Admins {
+		admins[id] = struct{}{}
+	}
+
+	transcriber := opts.Transcriber
+	if transcriber == nil {
+		transcriber = ai
+	}
+
+	location := opts.Location
+	if location == nil {
+		location = time.Local
+	}
+
+	s := &Service{
 		repo:            repo,
 		ai:              ai,
+		transcriber:     transcriber,
+		limiter:         NewRateLimiter(repo, opts.AILimits),
 		logger:          log,
+		admins:          admins,
+		bells:           opts.BellSchedules,
+		defaultBells:    opts.DefaultBellSchedule,
+		location:        location,
+		holidays:        opts.Holidays,
+		campus:          opts.Campus,
 		schedules:       make(map[int64]map[time.Weekday][]domain.Lesson),
 		weeklySchedules: make(map[int64]string),
+		now:             time.Now,
+	}
+	s.aiLessonParsing.Store(opts.AILessonParsing)
+	return s
+}
+
+// SetAILimits заменяет лимиты AI-запросов (при перезагрузке конфига)
+func (s *Service) SetAILimits(limits map[domain.Role]Limits) {
+	s.limiter.SetLimits(limits)
+}
+
+// SetAIParams меняет параметры модели OpenAI
+func (s *Service) SetAIParams(params AIParams) {
+	s.ai.SetParams(params)
+}
+
+// SetAILessonParsing включает или выключает разбор пар через AI
+func (s *Service) SetAILessonParsing(enabled bool) {
+	s.aiLessonParsing.Store(enabled)
+}
+
+// UserRole возвращает роль пользователя
+func (s *Service) UserRole(userID int64) domain.Role {
+	if _, ok := s.admins[userID]; ok {
+		return domain.RoleAdmin
 	}
+	return domain.RoleUser
 }
 
 func (s *Service) SaveWeeklyScheduleImage(ctx context.Context, userID int64, imageURL string) error {
@@ -48,7 +132,11 @@ func (s *Service) GetWeeklyScheduleImage(ctx context.Context, userID int64) (str
 
 func (s *Service) ProcessStartCommand(ctx context.Context, userID int64) error {
 	s.logger.Infow("Processing start command", "userID", userID)
+	return s.EnsureUser(ctx, userID)
+}
 
+// EnsureUser регистрирует пользователя, если его еще нет
+func (s *Service) EnsureUser(ctx context.Context, userID int64) error {
 	exists, err := s.repo.UserExists(ctx, userID)
 	if err != nil {
 		return err
@@ -61,29 +149,249 @@ func (s *Service) ProcessStartCommand(ctx context.Context, userID int64) error {
 	return nil
 }
 
-func (s *Service) SaveSchedule(ctx context.Context, userID int64, day time.Weekday, lessons []domain.Lesson) {
+var (
+	// ErrScheduleNotFound возвращается, если расписание на день не заполнено
+	ErrScheduleNotFound = errors.New("schedule not found")
+	// ErrLessonNotFound возвращается, если пары с таким ID нет в расписании дня
+	ErrLessonNotFound = errors.New("lesson not found")
+)
+
+// SaveSchedule проверяет и сохраняет пары дня, упорядочив их по времени начала.
+// Если найдены ошибки, возвращается *ValidationError и ничего не сохраняется;
+// иначе возвращаются предупреждения (пересечения, слишком длинные пары и перерывы).
+func (s *Service) SaveSchedule(ctx context.Context, userID int64, day time.Weekday, lessons []domain.Lesson) ([]ValidationIssue, error) {
+	issues := ValidateDay(lessons)
+	if HasErrors(issues) {
+		return nil, &ValidationError{Issues: issues}
+	}
+
+	lessons = append([]domain.Lesson(nil), lessons...)
+	SortLessons(lessons)
+	s.linkTeachers(ctx, lessons)
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for i := range lessons {
+		if lessons[i].ID == "" {
+			lessons[i].ID = newID()
+		}
+	}
+
 	if _, ok := s.schedules[userID]; !ok {
 		s.schedules[userID] = make(map[time.Weekday][]domain.Lesson)
 	}
 	s.schedules[userID][day] = lessons
+
+	return issues, nil
 }
 
 func (s *Service) GetSchedule(ctx context.Context, userID int64, day time.Weekday) ([]domain.Lesson, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	userSchedule, ok := s.schedules[userID]
 	if !ok {
-		return nil, fmt.Errorf("schedule not found for user %d", userID)
+		return nil, fmt.Errorf("%w for user %d", ErrScheduleNotFound, userID)
 	}
 
 	lessons, ok := userSchedule[day]
 	if !ok {
-		return nil, fmt.Errorf("schedule not found for day %v", day)
+		return nil, fmt.Errorf("%w for day %v", ErrScheduleNotFound, day)
+	}
+
+	return append([]domain.Lesson(nil), lessons...), nil
+}
+
+// GetLesson возвращает пару по ID
+func (s *Service) GetLesson(ctx context.Context, userID int64, day time.Weekday, lessonID string) (domain.Lesson, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	lessons := s.schedules[userID][day]
+	i := lessonIndex(lessons, lessonID)
+	if i < 0 {
+		return domain.Lesson{}, ErrLessonNotFound
+	}
+	return lessons[i], nil
+}
+
+// UpdateLessonField изменяет одно поле пары. Значение разбирается
+// так же, как ответы на уточняющие вопросы в мастере ввода.
+// День проверяется целиком и заново упорядочивается по времени начала;
+// возвращаются предупреждения, как в SaveSchedule.
+func (s *Service) UpdateLessonField(ctx context.Context, userID int64, day time.Weekday, lessonID string, field LessonField, value string) (domain.Lesson, []ValidationIssue, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lessons := s.schedules[userID][day]
+	i := lessonIndex(lessons, lessonID)
+	if i < 0 {
+		return domain.Lesson{}, nil, ErrLessonNotFound
+	}
+
+	draft := LessonDraft{Lesson: lessons[i], Missing: []LessonField{field}}
+	// Для текстовых полей "-" должно очищать значение
+	switch field {
+	case FieldLocation:
+		draft.Lesson.Location = ""
+	case FieldTeacher:
+		draft.Lesson.Teacher = ""
+	}
+	if err := draft.Fill(value); err != nil {
+		return domain.Lesson{}, nil, err
+	}
+
+	if field == FieldTeacher {
+		// Связь со справочником пересчитывается по новой фамилии
+		draft.Lesson.TeacherID = ""
+		linked := []domain.Lesson{draft.Lesson}
+		s.linkTeachers(ctx, linked)
+		draft.Lesson = linked[0]
+	}
+
+	// Пара проверяется вместе с остальными парами дня: новое время может пересечься с ними
+	updated := append([]domain.Lesson(nil), lessons...)
+	updated[i] = draft.Lesson
+	issues := ValidateDay(updated)
+	if HasErrors(issues) {
+		return domain.Lesson{}, nil, &ValidationError{Issues: issues}
 	}
 
-	return lessons, nil
+	SortLessons(updated)
+	s.schedules[userID][day] = updated
+	return draft.Lesson, issues, nil
+}
+
+// DeleteLesson удаляет пару из расписания дня
+func (s *Service) DeleteLesson(ctx context.Context, userID int64, day time.Weekday, lessonID string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lessons := s.schedules[userID][day]
+	i := lessonIndex(lessons, lessonID)
+	if i < 0 {
+		return ErrLessonNotFound
+	}
+
+	s.schedules[userID][day] = append(lessons[:i:i], lessons[i+1:]...)
+	return nil
+}
+
+// MoveLesson меняет пару местами с соседней (delta = -1 - с предыдущей, 1 - со следующей).
+// Пары обмениваются временем (и номером по звонкам), поэтому порядок дня
+// по-прежнему совпадает с порядком по времени начала.
+func (s *Service) MoveLesson(ctx context.Context, userID int64, day time.Weekday, lessonID string, delta int) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	lessons := s.schedules[userID][day]
+	i := lessonIndex(lessons, lessonID)
+	if i < 0 {
+		return ErrLessonNotFound
+	}
+
+	j := i + delta
+	if j < 0 || j >= len(lessons) {
+		return nil
+	}
+
+	moved := append([]domain.Lesson(nil), lessons...)
+	a, b := &moved[i], &moved[j]
+	a.StartTime, b.StartTime = b.StartTime, a.StartTime
+	a.EndTime, b.EndTime = b.EndTime, a.EndTime
+	a.Slot, b.Slot = b.Slot, a.Slot
+	moved[i], moved[j] = moved[j], moved[i]
+
+	SortLessons(moved)
+	s.schedules[userID][day] = moved
+	return nil
+}
+
+// lessonIndex ищет пару по ID, -1 если не найдена
+func lessonIndex(lessons []domain.Lesson, lessonID string) int {
+	for i, l := range lessons {
+		if l.ID == lessonID {
+			return i
+		}
+	}
+	return -1
+}
+
+// newID генерирует короткий ID (пары, преподавателя), помещающийся в callback data
+func newID() string {
+	buf := make([]byte, 4)
+	if _, err := rand.Read(buf); err != nil {
+		return fmt.Sprintf("%x", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(buf)
+}
+
+// ProcessAIQuestion отправляет вопрос AI-помощнику с учетом лимитов пользователя
+func (s *Service) ProcessAIQuestion(ctx context.Context, userID int64, question string) (string, error) {
+	reservation, err := s.limiter.Allow(ctx, userID, s.UserRole(userID))
+	if err != nil {
+		return "", err
+	}
+
+	answer, err := s.ai.HandleQuestion(ctx, question)
+	if err != nil {
+		s.releaseQuota(ctx, reservation)
+		return "", err
+	}
+
+	return answer, nil
+}
+
+// Transcript - распознанное голосовое сообщение. Место в квоте AI за него уже занято
+// и покрывает ответ на вопрос через AnswerTranscript.
+type Transcript struct {
+	Text        string
+	reservation *Reservation
+}
+
+// TranscribeVoice распознает голосовое сообщение пользователя на языке language
+// (пустой язык - Whisper определяет его сам).
+// Распознавание проходит через тот же лимитер, что и вопросы к AI.
+func (s *Service) TranscribeVoice(ctx context.Context, userID int64, audio io.Reader, filename, language string) (Transcript, error) {
+	reservation, err := s.limiter.Allow(ctx, userID, s.UserRole(userID))
+	if err != nil {
+		return Transcript{}, err
+	}
+
+	text, err := s.transcriber.Transcribe(ctx, audio, filename, language)
+	if err != nil {
+		s.releaseQuota(ctx, reservation)
+		return Transcript{}, fmt.Errorf("transcribe voice: %w", err)
+	}
+
+	return Transcript{Text: text, reservation: reservation}, nil
+}
+
+// AnswerTranscript отвечает на вопрос из голосового сообщения. Лимиты уже проверены
+// в TranscribeVoice, поэтому голосовой вопрос занимает одно место в квоте, а не два.
+func (s *Service) AnswerTranscript(ctx context.Context, transcript Transcript) (string, error) {
+	answer, err := s.ai.HandleQuestion(ctx, transcript.Text)
+	if err != nil {
+		if transcript.reservation != nil {
+			s.releaseQuota(ctx, transcript.reservation)
+		}
+		return "", err
+	}
+
+	return answer, nil
+}
+
+// releaseQuota возвращает место в квотах после неудачного AI-запроса
+func (s *Service) releaseQuota(ctx context.Context, reservation *Reservation) {
+	if err := reservation.Release(ctx); err != nil {
+		s.logger.Errorw("Failed to release AI quota", "error", err, "userID", reservation.userID)
+	}
 }
 
-func (s *Service) ProcessAIQuestion(ctx context.Context, question string) (string, error) {
-	return s.ai.HandleQuestion(ctx, question)
+// GetAIUsage возвращает использование AI пользователем за текущий день и месяц
+func (s *Service) GetAIUsage(ctx context.Context, userID int64) (Usage, error) {
+	return s.limiter.Usage(ctx, userID, s.UserRole(userID))
 }
 
 func (s *Service) ProcessScheduleImage(ctx context.Context, userID int64, imageURL string) (string, error) {
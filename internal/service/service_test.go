@@ -0,0 +1,137 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/repository/inmemory"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// newTestService создает сервис поверх репозитория в памяти без ключа OpenAI
+func newTestService(t *testing.T, opts Options) (*Service, *inmemory.InMemoryRepository) {
+	t.Helper()
+	repo := inmemory.New()
+	if opts.Location == nil {
+		opts.Location = time.UTC
+	}
+	return New(repo, NewAIService("", nil), logger.New("error"), opts), repo
+}
+
+// at разбирает время дня "15:04" так же, как ввод пользователя
+func at(hhmm string) time.Time {
+	t, err := time.Parse("15:04", hhmm)
+	if err != nil {
+		panic(err)
+	}
+	return t
+}
+
+func lesson(name, start, end string) domain.Lesson {
+	return domain.Lesson{Name: name, StartTime: at(start), EndTime: at(end)}
+}
+
+func TestUpdateLessonFieldValidatesDay(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t, Options{})
+
+	if _, err := svc.SaveSchedule(ctx, 1, time.Monday, []domain.Lesson{
+		lesson("Математика", "08:30", "10:00"),
+		lesson("Физика", "10:10", "11:40"),
+		lesson("История", "12:10", "13:40"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	day, _ := svc.GetSchedule(ctx, 1, time.Monday)
+	history := day[2]
+
+	// Перенос на время другой пары - предупреждение о пересечении, пара сохраняется
+	updated, issues, err := svc.UpdateLessonField(ctx, 1, time.Monday, history.ID, FieldTime, "09:00-10:30")
+	if err != nil {
+		t.Fatalf("UpdateLessonField: %v", err)
+	}
+	if updated.ID != history.ID || !updated.StartTime.Equal(at("09:00")) {
+		t.Errorf("updated = %+v", updated)
+	}
+	overlaps := 0
+	for _, issue := range issues {
+		if issue.Kind == IssueOverlap {
+			overlaps++
+		}
+	}
+	if overlaps != 2 {
+		t.Errorf("issues = %v, want overlaps with Математика and Физика", issues)
+	}
+
+	// День заново упорядочен по времени начала
+	day, _ = svc.GetSchedule(ctx, 1, time.Monday)
+	var names []string
+	for _, l := range day {
+		names = append(names, l.Name)
+	}
+	if strings.Join(names, ",") != "Математика,История,Физика" {
+		t.Errorf("order after update = %v", names)
+	}
+
+	// Ошибка не сохраняется
+	_, _, err = svc.UpdateLessonField(ctx, 1, time.Monday, history.ID, FieldTime, "11:00-10:00")
+	var validationErr *ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("err = %v, want ValidationError", err)
+	}
+	if got, _ := svc.GetLesson(ctx, 1, time.Monday, history.ID); !got.StartTime.Equal(at("09:00")) {
+		t.Errorf("rejected change was saved: %+v", got)
+	}
+
+	if _, _, err := svc.UpdateLessonField(ctx, 1, time.Monday, "missing", FieldName, "Химия"); !errors.Is(err, ErrLessonNotFound) {
+		t.Errorf("err = %v, want ErrLessonNotFound", err)
+	}
+}
+
+func TestMoveLessonSwapsTimes(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t, Options{})
+
+	if _, err := svc.SaveSchedule(ctx, 1, time.Monday, []domain.Lesson{
+		lesson("Математика", "08:30", "10:00"),
+		lesson("Физика", "10:10", "11:40"),
+		lesson("История", "12:10", "13:00"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	day, _ := svc.GetSchedule(ctx, 1, time.Monday)
+
+	if err := svc.MoveLesson(ctx, 1, time.Monday, day[2].ID, -1); err != nil {
+		t.Fatalf("MoveLesson: %v", err)
+	}
+	// Перемещение с краю ничего не меняет
+	if err := svc.MoveLesson(ctx, 1, time.Monday, day[0].ID, -1); err != nil {
+		t.Fatalf("MoveLesson: %v", err)
+	}
+	// Сортировка при следующем изменении не должна откатить перемещение
+	if _, _, err := svc.UpdateLessonField(ctx, 1, time.Monday, day[0].ID, FieldLocation, "101"); err != nil {
+		t.Fatalf("UpdateLessonField: %v", err)
+	}
+
+	got, _ := svc.GetSchedule(ctx, 1, time.Monday)
+	want := []struct{ name, start, end string }{
+		{"Математика", "08:30", "10:00"},
+		{"История", "10:10", "11:40"},
+		{"Физика", "12:10", "13:00"},
+	}
+	for i, w := range want {
+		l := got[i]
+		if l.Name != w.name || l.StartTime.Format("15:04") != w.start || l.EndTime.Format("15:04") != w.end {
+			t.Errorf("lesson %d = %s %s-%s, want %s %s-%s", i, l.Name,
+				l.StartTime.Format("15:04"), l.EndTime.Format("15:04"), w.name, w.start, w.end)
+		}
+	}
+
+	if err := svc.MoveLesson(ctx, 1, time.Monday, "missing", 1); !errors.Is(err, ErrLessonNotFound) {
+		t.Errorf("err = %v, want ErrLessonNotFound", err)
+	}
+}
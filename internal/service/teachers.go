@@ -0,0 +1,212 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+var (
+	// ErrForbidden - действие доступно только администраторам
+	ErrForbidden = errors.New("forbidden")
+	// ErrTeacherNotFound - в справочнике нет подходящего преподавателя
+	ErrTeacherNotFound = errors.New("teacher not found")
+	// ErrTeacherAmbiguous - запросу соответствует несколько преподавателей
+	ErrTeacherAmbiguous = errors.New("teacher query is ambiguous")
+)
+
+// TeacherLesson - пара с преподавателем в конкретный день
+type TeacherLesson struct {
+	Date   time.Time
+	Lesson domain.Lesson
+}
+
+// ListTeachers возвращает справочник преподавателей по алфавиту
+func (s *Service) ListTeachers(ctx context.Context) ([]domain.Teacher, error) {
+	teachers, err := s.repo.ListTeachers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(teachers, func(i, j int) bool {
+		return teachers[i].FullName < teachers[j].FullName
+	})
+	return teachers, nil
+}
+
+// FindTeachers ищет преподавателей по фамилии (регистр и падеж "у Иванова" не важны)
+func (s *Service) FindTeachers(ctx context.Context, query string) ([]domain.Teacher, error) {
+	teachers, err := s.ListTeachers(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var found []domain.Teacher
+	for _, t := range teachers {
+		if t.ID == query || teacherMatches(t, query) {
+			found = append(found, t)
+		}
+	}
+	return found, nil
+}
+
+// AddTeacher добавляет преподавателя в справочник (только для администраторов)
+func (s *Service) AddTeacher(ctx context.Context, actorID int64, teacher domain.Teacher) (domain.Teacher, error) {
+	if s.UserRole(actorID) != domain.RoleAdmin {
+		return domain.Teacher{}, ErrForbidden
+	}
+
+	teacher.FullName = strings.TrimSpace(teacher.FullName)
+	if teacher.FullName == "" {
+		return domain.Teacher{}, errors.New("empty teacher name")
+	}
+	if teacher.ID == "" {
+		teacher.ID = newID()
+	}
+
+	if err := s.repo.SaveTeacher(ctx, teacher); err != nil {
+		return domain.Teacher{}, err
+	}
+
+	s.logger.Infow("Teacher saved", "teacherID", teacher.ID, "actorID", actorID)
+	return teacher, nil
+}
+
+// DeleteTeacher удаляет преподавателя по ID или однозначной фамилии (только для администраторов)
+func (s *Service) DeleteTeacher(ctx context.Context, actorID int64, query string) (domain.Teacher, error) {
+	if s.UserRole(actorID) != domain.RoleAdmin {
+		return domain.Teacher{}, ErrForbidden
+	}
+
+	teacher, err := s.findOneTeacher(ctx, query)
+	if err != nil {
+		return domain.Teacher{}, err
+	}
+
+	if err := s.repo.DeleteTeacher(ctx, teacher.ID); err != nil {
+		return domain.Teacher{}, err
+	}
+
+	s.logger.Infow("Teacher deleted", "teacherID", teacher.ID, "actorID", actorID)
+	return teacher, nil
+}
+
+// UpdateTeacher изменяет запись справочника, найденную по ID или однозначной фамилии
+// (только для администраторов). Пустое поле в changes оставляет прежнее значение,
+// "-" очищает его.
+func (s *Service) UpdateTeacher(ctx context.Context, actorID int64, query string, changes domain.Teacher) (domain.Teacher, error) {
+	if s.UserRole(actorID) != domain.RoleAdmin {
+		return domain.Teacher{}, ErrForbidden
+	}
+
+	teacher, err := s.findOneTeacher(ctx, query)
+	if err != nil {
+		return domain.Teacher{}, err
+	}
+
+	teacher.FullName = mergeTeacherField(teacher.FullName, changes.FullName)
+	teacher.Department = mergeTeacherField(teacher.Department, changes.Department)
+	teacher.Contacts = mergeTeacherField(teacher.Contacts, changes.Contacts)
+	teacher.OfficeHours = mergeTeacherField(teacher.OfficeHours, changes.OfficeHours)
+	if teacher.FullName == "" {
+		return domain.Teacher{}, errors.New("empty teacher name")
+	}
+
+	if err := s.repo.SaveTeacher(ctx, teacher); err != nil {
+		return domain.Teacher{}, err
+	}
+
+	s.logger.Infow("Teacher updated", "teacherID", teacher.ID, "actorID", actorID)
+	return teacher, nil
+}
+
+// mergeTeacherField применяет изменение поля: "" - без изменений, "-" - очистить
+func mergeTeacherField(current, change string) string {
+	switch change = strings.TrimSpace(change); change {
+	case "":
+		return current
+	case "-":
+		return ""
+	default:
+		return change
+	}
+}
+
+// TeacherWeek возвращает пары пользователя с преподавателем на текущей неделе
+func (s *Service) TeacherWeek(ctx context.Context, userID int64, teacher domain.Teacher) ([]TeacherLesson, error) {
+	now := s.Now(ctx, userID)
+	monday := now.AddDate(0, 0, -(int(now.Weekday())+6)%7)
+
+	var result []TeacherLesson
+	for offset := 0; offset < 7; offset++ {
+		date := monday.AddDate(0, 0, offset)
+		lessons, err := s.LessonsOn(ctx, userID, date)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, lesson := range lessons {
+			if lesson.TeacherID == teacher.ID || (lesson.TeacherID == "" && teacherMatches(teacher, lesson.Teacher)) {
+				result = append(result, TeacherLesson{Date: date, Lesson: lesson})
+			}
+		}
+	}
+
+	return result, nil
+}
+
+// findOneTeacher ищет ровно одного преподавателя по запросу
+func (s *Service) findOneTeacher(ctx context.Context, query string) (domain.Teacher, error) {
+	found, err := s.FindTeachers(ctx, query)
+	if err != nil {
+		return domain.Teacher{}, err
+	}
+
+	switch len(found) {
+	case 0:
+		return domain.Teacher{}, ErrTeacherNotFound
+	case 1:
+		return found[0], nil
+	default:
+		return domain.Teacher{}, ErrTeacherAmbiguous
+	}
+}
+
+// linkTeachers связывает пары со справочником по фамилии преподавателя
+func (s *Service) linkTeachers(ctx context.Context, lessons []domain.Lesson) {
+	for i := range lessons {
+		if lessons[i].Teacher == "" || lessons[i].TeacherID != "" {
+			continue
+		}
+
+		teacher, err := s.findOneTeacher(ctx, lessons[i].Teacher)
+		if err != nil {
+			continue
+		}
+		lessons[i].TeacherID = teacher.ID
+	}
+}
+
+// teacherMatches сравнивает фамилию из справочника с текстом вроде "Иванов И.И." или "Иванова"
+func teacherMatches(teacher domain.Teacher, text string) bool {
+	surname := strings.ToLower(firstWord(teacher.FullName))
+	query := strings.ToLower(firstWord(text))
+	if surname == "" || query == "" {
+		return false
+	}
+
+	return surname == query || surname == strings.ToLower(nominative(firstWord(text)))
+}
+
+// firstWord возвращает первое слово строки
+func firstWord(text string) string {
+	fields := strings.Fields(text)
+	if len(fields) == 0 {
+		return ""
+	}
+	return fields[0]
+}
@@ -0,0 +1,47 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+func TestUpdateTeacher(t *testing.T) {
+	ctx := context.Background()
+	svc, _ := newTestService(t, Options{Admins: []int64{1}})
+
+	added, err := svc.AddTeacher(ctx, 1, domain.Teacher{
+		FullName:    "Иванов Иван Иванович",
+		Department:  "Физика",
+		Contacts:    "ivanov@example.com",
+		OfficeHours: "Пн 15:00",
+	})
+	if err != nil {
+		t.Fatalf("AddTeacher: %v", err)
+	}
+
+	// Пустое поле не меняется, "-" очищает поле
+	updated, err := svc.UpdateTeacher(ctx, 1, "Иванова", domain.Teacher{Department: " Математика ", Contacts: "-"})
+	if err != nil {
+		t.Fatalf("UpdateTeacher: %v", err)
+	}
+	want := domain.Teacher{ID: added.ID, FullName: "Иванов Иван Иванович", Department: "Математика", OfficeHours: "Пн 15:00"}
+	if updated != want {
+		t.Errorf("updated = %+v, want %+v", updated, want)
+	}
+	if found, _ := svc.FindTeachers(ctx, added.ID); len(found) != 1 || found[0] != want {
+		t.Errorf("stored = %+v, want %+v", found, want)
+	}
+
+	if _, err := svc.UpdateTeacher(ctx, 2, added.ID, domain.Teacher{Department: "История"}); !errors.Is(err, ErrForbidden) {
+		t.Errorf("non-admin err = %v, want ErrForbidden", err)
+	}
+	if _, err := svc.UpdateTeacher(ctx, 1, "Петров", domain.Teacher{Department: "История"}); !errors.Is(err, ErrTeacherNotFound) {
+		t.Errorf("unknown teacher err = %v, want ErrTeacherNotFound", err)
+	}
+	if _, err := svc.UpdateTeacher(ctx, 1, added.ID, domain.Teacher{FullName: "-"}); err == nil {
+		t.Error("clearing the name returned nil error")
+	}
+}
@@ -0,0 +1,12 @@
+package service
+
+import (
+	"context"
+	"io"
+)
+
+// Transcriber преобразует аудиозапись в текст.
+// language - код языка речи ISO 639-1, пустой - определить автоматически.
+type Transcriber interface {
+	Transcribe(ctx context.Context, audio io.Reader, filename, language string) (string, error)
+}
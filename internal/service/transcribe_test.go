@@ -0,0 +1,118 @@
+package service
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+	"github.com/polyk005/tg_bot/internal/repository/inmemory"
+	"github.com/polyk005/tg_bot/pkg/logger"
+)
+
+// fakeTranscriber возвращает заранее заданный текст, не обращаясь к Whisper
+type fakeTranscriber struct {
+	Text string
+	Err  error
+
+	mu       sync.Mutex
+	language string // Язык из последнего вызова Transcribe
+}
+
+func (f *fakeTranscriber) Transcribe(ctx context.Context, audio io.Reader, filename, language string) (string, error) {
+	f.mu.Lock()
+	f.language = language
+	f.mu.Unlock()
+	if f.Err != nil {
+		return "", f.Err
+	}
+	return f.Text, nil
+}
+
+func (f *fakeTranscriber) lastLanguage() string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return f.language
+}
+
+// newFakeOpenAI создает AIService, который обращается к поддельному API:
+// chat completions отвечают текстом answer или ошибкой 500, если fail вернул true
+func newFakeOpenAI(t *testing.T, answer string, fail func() bool) (*AIService, *atomic.Int32) {
+	t.Helper()
+	var calls atomic.Int32
+	ai := newAIServiceWithHandler(t, func(w http.ResponseWriter, r *http.Request) {
+		calls.Add(1)
+		w.Header().Set("Content-Type", "application/json")
+		if fail != nil && fail() {
+			w.WriteHeader(http.StatusInternalServerError)
+			_, _ = w.Write([]byte(`{"error":{"message":"overloaded","type":"server_error"}}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"id":"1","object":"chat.completion","choices":[{"index":0,"message":{"role":"assistant","content":"` +
+			answer + `"},"finish_reason":"stop"}],"usage":{"prompt_tokens":1,"completion_tokens":1,"total_tokens":2}}`))
+	})
+	return ai, &calls
+}
+
+func TestVoiceQuestionUsesOneReservation(t *testing.T) {
+	ctx := context.Background()
+	ai, calls := newFakeOpenAI(t, "Физика в 10:10", nil)
+	transcriber := &fakeTranscriber{Text: "когда физика"}
+	svc := New(inmemory.New(), ai, logger.New("error"), Options{
+		Transcriber: transcriber,
+		AILimits:    map[domain.Role]Limits{domain.RoleUser: {RatePerMinute: 1, Burst: 1, Daily: 10}},
+	})
+
+	transcript, err := svc.TranscribeVoice(ctx, 1, strings.NewReader("ogg"), "voice.ogg", "en")
+	if err != nil {
+		t.Fatalf("TranscribeVoice: %v", err)
+	}
+	if got := transcriber.lastLanguage(); got != "en" {
+		t.Errorf("transcriber got language %q, want en", got)
+	}
+	if transcript.Text != "когда физика" {
+		t.Errorf("transcript = %q", transcript.Text)
+	}
+
+	// Bucket пуст после распознавания, но ответ на тот же вопрос не должен упереться в лимит
+	answer, err := svc.AnswerTranscript(ctx, transcript)
+	if err != nil {
+		t.Fatalf("AnswerTranscript: %v", err)
+	}
+	if answer != "Физика в 10:10" || calls.Load() != 1 {
+		t.Errorf("answer = %q after %d API calls", answer, calls.Load())
+	}
+
+	usage, err := svc.GetAIUsage(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if usage.Day != 1 {
+		t.Errorf("voice question used %d of the daily quota, want 1", usage.Day)
+	}
+}
+
+func TestAnswerTranscriptReleasesQuotaOnFailure(t *testing.T) {
+	ctx := context.Background()
+	ai, _ := newFakeOpenAI(t, "", func() bool { return true })
+	svc := New(inmemory.New(), ai, logger.New("error"), Options{
+		Transcriber: &fakeTranscriber{Text: "когда физика"},
+		AILimits:    map[domain.Role]Limits{domain.RoleUser: {Daily: 10}},
+	})
+
+	transcript, err := svc.TranscribeVoice(ctx, 1, strings.NewReader("ogg"), "voice.ogg", "ru")
+	if err != nil {
+		t.Fatalf("TranscribeVoice: %v", err)
+	}
+	if _, err := svc.AnswerTranscript(ctx, transcript); err == nil {
+		t.Fatal("AnswerTranscript returned nil error")
+	}
+
+	if usage, _ := svc.GetAIUsage(ctx, 1); usage.Day != 0 {
+		t.Errorf("failed voice question used %d of the daily quota", usage.Day)
+	}
+}
@@ -0,0 +1,135 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// UserData - все, что бот хранит о пользователе (для /mydata)
+type UserData struct {
+	ExportedAt          time.Time        `json:"exported_at"`
+	Profile             UserDataProfile  `json:"profile"`
+	Settings            UserDataSettings `json:"settings"`
+	AIUsage             UserDataAIUsage  `json:"ai_usage"`
+	Schedule            []UserDataLesson `json:"schedule"`
+	WeeklyScheduleImage string           `json:"weekly_schedule_image,omitempty"`
+}
+
+type UserDataProfile struct {
+	ID           int64     `json:"id"`
+	Username     string    `json:"username,omitempty"`
+	FirstName    string    `json:"first_name,omitempty"`
+	LastName     string    `json:"last_name,omitempty"`
+	LanguageCode string    `json:"language_code,omitempty"`
+	Language     string    `json:"language,omitempty"`
+	CreatedAt    time.Time `json:"created_at"`
+	LastSeen     time.Time `json:"last_seen"`
+	Blocked      bool      `json:"blocked_bot,omitempty"`
+}
+
+type UserDataSettings struct {
+	BellSchedule string `json:"bell_schedule,omitempty"`
+	TimeZone     string `json:"time_zone,omitempty"`
+}
+
+type UserDataAIUsage struct {
+	Day        string `json:"day,omitempty"`
+	DayCount   int    `json:"day_count"`
+	Month      string `json:"month,omitempty"`
+	MonthCount int    `json:"month_count"`
+}
+
+type UserDataLesson struct {
+	Weekday  string `json:"weekday"`
+	Name     string `json:"name"`
+	Start    string `json:"start"`
+	End      string `json:"end"`
+	Location string `json:"location,omitempty"`
+	Teacher  string `json:"teacher,omitempty"`
+	Slot     int    `json:"slot,omitempty"`
+	Week     int    `json:"week,omitempty"`
+}
+
+// ExportUserData собирает все записи пользователя из хранилища и памяти сервиса
+func (s *Service) ExportUserData(ctx context.Context, userID int64) (UserData, error) {
+	user, err := s.repo.GetUser(ctx, userID)
+	if err != nil {
+		return UserData{}, fmt.Errorf("get user: %w", err)
+	}
+	settings, err := s.repo.GetSettings(ctx, userID)
+	if err != nil {
+		return UserData{}, fmt.Errorf("get settings: %w", err)
+	}
+	usage, err := s.repo.GetAIUsage(ctx, userID)
+	if err != nil {
+		return UserData{}, fmt.Errorf("get ai usage: %w", err)
+	}
+
+	data := UserData{
+		ExportedAt: s.now(),
+		Profile: UserDataProfile{
+			ID:           userID,
+			Username:     user.Username,
+			FirstName:    user.FirstName,
+			LastName:     user.LastName,
+			LanguageCode: user.LanguageCode,
+			Language:     user.Language,
+			CreatedAt:    user.CreatedAt,
+			LastSeen:     user.LastSeen,
+			Blocked:      user.Blocked,
+		},
+		Settings: UserDataSettings{
+			BellSchedule: settings.BellSchedule,
+			TimeZone:     settings.TimeZone,
+		},
+		AIUsage: UserDataAIUsage{
+			Day:        usage.Day,
+			DayCount:   usage.DayCount,
+			Month:      usage.Month,
+			MonthCount: usage.MonthCount,
+		},
+		Schedule: []UserDataLesson{},
+	}
+
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	// Неделя с понедельника, как в /week
+	for i := 1; i <= 7; i++ {
+		day := time.Weekday(i % 7)
+		for _, l := range s.schedules[userID][day] {
+			data.Schedule = append(data.Schedule, UserDataLesson{
+				Weekday:  day.String(),
+				Name:     l.Name,
+				Start:    l.StartTime.Format("15:04"),
+				End:      l.EndTime.Format("15:04"),
+				Location: l.Location,
+				Teacher:  l.Teacher,
+				Slot:     l.Slot,
+				Week:     l.Week,
+			})
+		}
+	}
+	data.WeeklyScheduleImage = s.weeklySchedules[userID]
+
+	return data, nil
+}
+
+// DeleteUserData удаляет все данные пользователя: профиль, настройки, счетчики AI,
+// расписание и состояние ограничителя запросов
+func (s *Service) DeleteUserData(ctx context.Context, userID int64) error {
+	if err := s.repo.DeleteUser(ctx, userID); err != nil {
+		return fmt.Errorf("delete user: %w", err)
+	}
+
+	s.mu.Lock()
+	delete(s.schedules, userID)
+	delete(s.weeklySchedules, userID)
+	s.mu.Unlock()
+
+	s.limiter.Forget(userID)
+
+	s.logger.Infow("User data deleted", "userID", userID)
+	return nil
+}
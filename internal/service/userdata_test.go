@@ -0,0 +1,100 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+func TestExportAndDeleteUserData(t *testing.T) {
+	ctx := context.Background()
+	svc, repo := newTestService(t, Options{})
+	exportedAt := time.Date(2025, 10, 20, 12, 0, 0, 0, time.UTC)
+	svc.now = func() time.Time { return exportedAt }
+	const userID = 42
+
+	if err := svc.TouchUser(ctx, domain.User{ID: userID, Username: "student", FirstName: "Анна"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.SaveSchedule(ctx, userID, time.Tuesday, []domain.Lesson{
+		lesson("Физика", "12:10", "13:40"),
+		lesson("Математика", "08:30", "10:00"),
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.SaveSchedule(ctx, userID, time.Monday, []domain.Lesson{lesson("История", "10:10", "11:40")}); err != nil {
+		t.Fatal(err)
+	}
+	if err := svc.SaveWeeklyScheduleImage(ctx, userID, "https://example.com/week.png"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := svc.limiter.Allow(ctx, userID, domain.RoleUser); err != nil {
+		t.Fatal(err)
+	}
+
+	data, err := svc.ExportUserData(ctx, userID)
+	if err != nil {
+		t.Fatalf("ExportUserData: %v", err)
+	}
+	if !data.ExportedAt.Equal(exportedAt) {
+		t.Errorf("exported_at = %s, want the service clock %s", data.ExportedAt, exportedAt)
+	}
+	if data.Profile.ID != userID || data.Profile.Username != "student" || data.Profile.FirstName != "Анна" {
+		t.Errorf("profile = %+v", data.Profile)
+	}
+	if data.WeeklyScheduleImage != "https://example.com/week.png" {
+		t.Errorf("weekly image = %q", data.WeeklyScheduleImage)
+	}
+	if data.AIUsage.DayCount != 1 {
+		t.Errorf("ai usage day count = %d, want 1", data.AIUsage.DayCount)
+	}
+
+	// Неделя с понедельника, внутри дня - по времени
+	want := []struct{ weekday, name, start string }{
+		{"Monday", "История", "10:10"},
+		{"Tuesday", "Математика", "08:30"},
+		{"Tuesday", "Физика", "12:10"},
+	}
+	if len(data.Schedule) != len(want) {
+		t.Fatalf("schedule has %d lessons, want %d: %+v", len(data.Schedule), len(want), data.Schedule)
+	}
+	for i, w := range want {
+		got := data.Schedule[i]
+		if got.Weekday != w.weekday || got.Name != w.name || got.Start != w.start {
+			t.Errorf("schedule[%d] = %+v, want %+v", i, got, w)
+		}
+	}
+
+	if err := svc.DeleteUserData(ctx, userID); err != nil {
+		t.Fatalf("DeleteUserData: %v", err)
+	}
+
+	if exists, _ := repo.UserExists(ctx, userID); exists {
+		t.Error("user still exists after delete")
+	}
+	if _, err := svc.GetSchedule(ctx, userID, time.Tuesday); err == nil {
+		t.Error("schedule survived delete")
+	}
+	if _, ok, _ := svc.GetWeeklyScheduleImage(ctx, userID); ok {
+		t.Error("weekly schedule image survived delete")
+	}
+	if usage, _ := repo.GetAIUsage(ctx, userID); usage.DayCount != 0 {
+		t.Errorf("ai usage survived delete: %+v", usage)
+	}
+	svc.limiter.mu.Lock()
+	_, hasBucket := svc.limiter.buckets[userID]
+	svc.limiter.mu.Unlock()
+	if hasBucket {
+		t.Error("rate limiter state survived delete")
+	}
+
+	data, err = svc.ExportUserData(ctx, userID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(data.Schedule) != 0 || data.Profile.Username != "" {
+		t.Errorf("export after delete = %+v", data)
+	}
+}
@@ -0,0 +1,154 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+const (
+	// MaxLessonDuration - пары длиннее считаются подозрительными
+	MaxLessonDuration = 3 * time.Hour
+	// MaxGapDuration - перерыв между парами длиннее считается подозрительным
+	MaxGapDuration = 4 * time.Hour
+)
+
+// IssueKind - тип проблемы в расписании
+type IssueKind string
+
+const (
+	// IssueInvertedTime - пара заканчивается не позже, чем начинается (ошибка)
+	IssueInvertedTime IssueKind = "inverted_time"
+	// IssueEmptyName - у пары нет названия (ошибка)
+	IssueEmptyName IssueKind = "empty_name"
+	// IssueOverlap - пара пересекается с другой (предупреждение)
+	IssueOverlap IssueKind = "overlap"
+	// IssueTooLong - пара длиннее MaxLessonDuration (предупреждение)
+	IssueTooLong IssueKind = "too_long"
+	// IssueLongGap - перерыв после пары длиннее MaxGapDuration (предупреждение)
+	IssueLongGap IssueKind = "long_gap"
+)
+
+// ValidationIssue описывает одну найденную проблему
+type ValidationIssue struct {
+	Kind   IssueKind
+	Lesson domain.Lesson
+	// Other - вторая пара для пересечений и перерывов
+	Other *domain.Lesson
+}
+
+// IsError сообщает, что проблема не позволяет сохранить пару
+func (i ValidationIssue) IsError() bool {
+	return i.Kind == IssueInvertedTime || i.Kind == IssueEmptyName
+}
+
+// ValidationError возвращается, если среди проблем есть ошибки
+type ValidationError struct {
+	Issues []ValidationIssue
+}
+
+func (e *ValidationError) Error() string {
+	kinds := make([]string, 0, len(e.Issues))
+	for _, issue := range e.Issues {
+		kinds = append(kinds, fmt.Sprintf("%s (%s)", issue.Kind, issue.Lesson.Name))
+	}
+	return "invalid schedule: " + strings.Join(kinds, ", ")
+}
+
+// HasErrors проверяет, есть ли среди проблем ошибки
+func HasErrors(issues []ValidationIssue) bool {
+	for _, issue := range issues {
+		if issue.IsError() {
+			return true
+		}
+	}
+	return false
+}
+
+// SortLessons упорядочивает пары по времени начала
+func SortLessons(lessons []domain.Lesson) {
+	sort.SliceStable(lessons, func(i, j int) bool {
+		return lessons[i].StartTime.Before(lessons[j].StartTime)
+	})
+}
+
+// ValidateLesson проверяет одну пару и ее пересечения с уже введенными парами дня
+func ValidateLesson(lesson domain.Lesson, others []domain.Lesson) []ValidationIssue {
+	issues := lessonIssues(lesson)
+
+	for i := range others {
+		other := others[i]
+		if other.ID != "" && other.ID == lesson.ID {
+			continue
+		}
+		if overlaps(lesson, other) {
+			issues = append(issues, ValidationIssue{Kind: IssueOverlap, Lesson: lesson, Other: &other})
+		}
+	}
+
+	return issues
+}
+
+// ValidateDay проверяет все пары дня: время, длительность, пересечения и перерывы
+func ValidateDay(lessons []domain.Lesson) []ValidationIssue {
+	sorted := append([]domain.Lesson(nil), lessons...)
+	SortLessons(sorted)
+
+	var issues []ValidationIssue
+	for i := range sorted {
+		issues = append(issues, lessonIssues(sorted[i])...)
+	}
+
+	for i := 0; i < len(sorted); i++ {
+		for j := i + 1; j < len(sorted); j++ {
+			if !sorted[j].StartTime.Before(sorted[i].EndTime) {
+				break
+			}
+			if !sameWeeks(sorted[i], sorted[j]) {
+				continue
+			}
+			other := sorted[i]
+			issues = append(issues, ValidationIssue{Kind: IssueOverlap, Lesson: sorted[j], Other: &other})
+		}
+
+		if i+1 < len(sorted) && sorted[i+1].StartTime.Sub(sorted[i].EndTime) > MaxGapDuration {
+			next := sorted[i+1]
+			issues = append(issues, ValidationIssue{Kind: IssueLongGap, Lesson: sorted[i], Other: &next})
+		}
+	}
+
+	return issues
+}
+
+// lessonIssues проверяет пару саму по себе
+func lessonIssues(lesson domain.Lesson) []ValidationIssue {
+	var issues []ValidationIssue
+
+	if strings.TrimSpace(lesson.Name) == "" {
+		issues = append(issues, ValidationIssue{Kind: IssueEmptyName, Lesson: lesson})
+	}
+
+	duration := lesson.EndTime.Sub(lesson.StartTime)
+	switch {
+	case duration <= 0:
+		issues = append(issues, ValidationIssue{Kind: IssueInvertedTime, Lesson: lesson})
+	case duration > MaxLessonDuration:
+		issues = append(issues, ValidationIssue{Kind: IssueTooLong, Lesson: lesson})
+	}
+
+	return issues
+}
+
+// overlaps проверяет пересечение пар по времени в одну и ту же неделю
+func overlaps(a, b domain.Lesson) bool {
+	return sameWeeks(a, b) && a.StartTime.Before(b.EndTime) && b.StartTime.Before(a.EndTime)
+}
+
+// sameWeeks сообщает, что пары могут идти в одну неделю: пары по числителю
+// и по знаменателю в одно время не пересекаются
+func sameWeeks(a, b domain.Lesson) bool {
+	return a.Week == 0 || b.Week == 0 || a.Week == b.Week
+}
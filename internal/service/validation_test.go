@@ -0,0 +1,88 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/polyk005/tg_bot/internal/domain"
+)
+
+func weekLesson(name, start, end string, week int) domain.Lesson {
+	l := lesson(name, start, end)
+	l.Week = week
+	return l
+}
+
+func countIssues(issues []ValidationIssue, kind IssueKind) int {
+	n := 0
+	for _, issue := range issues {
+		if issue.Kind == kind {
+			n++
+		}
+	}
+	return n
+}
+
+func TestValidateDayOverlapsRespectWeekParity(t *testing.T) {
+	tests := []struct {
+		name     string
+		a, b     domain.Lesson
+		overlaps bool
+	}{
+		{
+			name:     "every week",
+			a:        lesson("Математика", "08:30", "10:00"),
+			b:        lesson("Физика", "09:00", "10:30"),
+			overlaps: true,
+		},
+		{
+			name: "numerator and denominator in one slot",
+			a:    weekLesson("Математика", "08:30", "10:00", 1),
+			b:    weekLesson("Физика", "08:30", "10:00", 2),
+		},
+		{
+			name:     "both numerator",
+			a:        weekLesson("Математика", "08:30", "10:00", 1),
+			b:        weekLesson("Физика", "08:30", "10:00", 1),
+			overlaps: true,
+		},
+		{
+			name:     "every week and denominator",
+			a:        lesson("Математика", "08:30", "10:00"),
+			b:        weekLesson("Физика", "09:00", "10:30", 2),
+			overlaps: true,
+		},
+		{
+			name: "back to back",
+			a:    lesson("Математика", "08:30", "10:00"),
+			b:    lesson("Физика", "10:00", "11:30"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			want := 0
+			if tt.overlaps {
+				want = 1
+			}
+			if n := countIssues(ValidateDay([]domain.Lesson{tt.a, tt.b}), IssueOverlap); n != want {
+				t.Errorf("ValidateDay overlaps = %d, want %d", n, want)
+			}
+			if n := countIssues(ValidateLesson(tt.b, []domain.Lesson{tt.a}), IssueOverlap); n != want {
+				t.Errorf("ValidateLesson overlaps = %d, want %d", n, want)
+			}
+		})
+	}
+}
+
+func TestValidateDayParityPairBesideWeeklyLesson(t *testing.T) {
+	// Пары по числителю и знаменателю в одно время, а еженедельная пара
+	// пересекается с обеими
+	issues := ValidateDay([]domain.Lesson{
+		weekLesson("Математика", "08:30", "10:00", 1),
+		weekLesson("Физика", "08:30", "10:00", 2),
+		lesson("История", "09:30", "11:00"),
+	})
+	if n := countIssues(issues, IssueOverlap); n != 2 {
+		t.Errorf("overlaps = %d, want 2: %v", n, issues)
+	}
+}
@@ -0,0 +1,18 @@
+package logger
+
+import "context"
+
+type ctxKey struct{}
+
+// WithContext сохраняет логгер в контексте (например, логгер апдейта с его полями)
+func WithContext(ctx context.Context, l Logger) context.Context {
+	return context.WithValue(ctx, ctxKey{}, l)
+}
+
+// FromContext возвращает логгер из контекста или fallback, если его там нет
+func FromContext(ctx context.Context, fallback Logger) Logger {
+	if l, ok := ctx.Value(ctxKey{}).(Logger); ok {
+		return l
+	}
+	return fallback
+}
@@ -1,6 +1,8 @@
 package logger
 
 import (
+	"fmt"
+
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
@@ -25,12 +27,22 @@ type Logger interface {
 	Errorw(msg string, keysAndValues ...interface{})
 	Fatalw(msg string, keysAndValues ...interface{})
 
+	// With возвращает дочерний логгер, добавляющий поля ко всем записям.
+	// Уровень логирования у дочерних логгеров общий с родителем.
+	With(keysAndValues ...interface{}) Logger
+
+	// SetLevel меняет уровень логирования на лету (debug, info, warn, error)
+	SetLevel(level string) error
+	// Level возвращает текущий уровень логирования
+	Level() string
+
 	Sync() error
 }
 
 // concreteLogger - реализация Logger на основе zap
 type concreteLogger struct {
-	zap *zap.SugaredLogger
+	zap   *zap.SugaredLogger
+	level zap.AtomicLevel
 }
 
 // New создает новый экземпляр логгера
@@ -41,7 +53,8 @@ func New(level string) Logger {
 	config.EncoderConfig.EncodeTime = zapcore.ISO8601TimeEncoder
 	config.EncoderConfig.EncodeLevel = zapcore.CapitalLevelEncoder
 
-	zapLogger, err := config.Build()
+	// Без пропуска кадра caller указывал бы на этот пакет, а не на место вызова
+	zapLogger, err := config.Build(zap.AddCallerSkip(1))
 	if err != nil {
 		// Fallback на стандартный логгер
 		zap.L().Error("Failed to create zap logger", zap.Error(err))
@@ -49,7 +62,8 @@ func New(level string) Logger {
 	}
 
 	return &concreteLogger{
-		zap: zapLogger.Sugar(),
+		zap:   zapLogger.Sugar(),
+		level: config.Level,
 	}
 }
 
@@ -129,6 +143,32 @@ func (l *concreteLogger) Fatalw(msg string, keysAndValues ...interface{}) {
 	l.zap.Fatalw(msg, keysAndValues...)
 }
 
+func (l *concreteLogger) With(keysAndValues ...interface{}) Logger {
+	return &concreteLogger{
+		zap:   l.zap.With(keysAndValues...),
+		level: l.level,
+	}
+}
+
+func (l *concreteLogger) Level() string {
+	return l.level.Level().String()
+}
+
+func (l *concreteLogger) SetLevel(level string) error {
+	switch level {
+	case "debug", "info", "warn", "error":
+	default:
+		return fmt.Errorf("unknown log level %q", level)
+	}
+
+	var zapLevel zapcore.Level
+	if err := zapLevel.UnmarshalText([]byte(level)); err != nil {
+		return err
+	}
+	l.level.SetLevel(zapLevel)
+	return nil
+}
+
 func (l *concreteLogger) Sync() error {
 	return l.zap.Sync()
 }
@@ -0,0 +1,44 @@
+package logger
+
+import (
+	"context"
+	"testing"
+)
+
+func TestSetLevelSharedWithChildren(t *testing.T) {
+	l := New("info")
+	child := l.With("update_id", 1)
+
+	if err := l.SetLevel("debug"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+	if child.Level() != "debug" {
+		t.Errorf("child level = %s, want debug", child.Level())
+	}
+
+	if err := child.SetLevel("error"); err != nil {
+		t.Fatalf("SetLevel: %v", err)
+	}
+	if l.Level() != "error" {
+		t.Errorf("parent level = %s, want error", l.Level())
+	}
+
+	if err := l.SetLevel("verbose"); err == nil {
+		t.Error("SetLevel accepted an unknown level")
+	}
+	if l.Level() != "error" {
+		t.Errorf("unknown level changed the level to %s", l.Level())
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	fallback := New("error")
+	if got := FromContext(context.Background(), fallback); got != fallback {
+		t.Error("empty context did not return the fallback")
+	}
+
+	reqLog := fallback.With("update_id", 1)
+	if got := FromContext(WithContext(context.Background(), reqLog), fallback); got != reqLog {
+		t.Error("context logger was not returned")
+	}
+}